@@ -1,22 +1,15 @@
 // Package main contains the cli implementation of the tool. It uses cobra
-// package for cli tool implementation.
+// package for cli tool implementation; the commands live in internal/cli.
 package main
 
 import (
 	"os"
 
-	"github.com/spf13/cobra"
+	"smf/internal/cli"
 )
 
 func main() {
-	rootCmd := &cobra.Command{
-		Use:   "smf",
-		Short: "Schema migration framework – TOML-first database schema tool",
-	}
-
-	// rootCmd.AddCommand(migrationCmd())
-
-	if err := rootCmd.Execute(); err != nil {
+	if err := cli.NewRootCmd().Execute(); err != nil {
 		os.Exit(1)
 	}
 }
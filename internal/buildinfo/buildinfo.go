@@ -0,0 +1,91 @@
+// Package buildinfo reports the version of the running smf binary. Release
+// builds set the version and commit with -ldflags:
+//
+//	go build -ldflags "-X smf/internal/buildinfo.version=v1.2.0 -X smf/internal/buildinfo.commit=abc1234" ./cmd/smf
+//
+// Other builds fall back to the module and VCS information Go embeds.
+package buildinfo
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"smf/internal/parser/toml"
+)
+
+// Set with -ldflags -X; empty in development builds.
+var (
+	version string
+	commit  string
+)
+
+// Info describes the running binary.
+type Info struct {
+	// Version is the release version, or "devel" when unknown.
+	Version string `json:"version"`
+	// Commit is the VCS revision the binary was built from, when known.
+	Commit string `json:"commit,omitempty"`
+	// CommitTime is the time of that revision in RFC 3339, when known.
+	CommitTime string `json:"commitTime,omitempty"`
+	// Modified reports uncommitted changes in the build tree.
+	Modified bool `json:"modified,omitempty"`
+	// GoVersion is the Go toolchain that built the binary.
+	GoVersion string `json:"goVersion"`
+	// SchemaFormat is the newest TOML schema_format the binary reads.
+	SchemaFormat int `json:"schemaFormat"`
+}
+
+// Read returns the build information of the running binary.
+func Read() Info {
+	info := Info{Version: version, Commit: commit, SchemaFormat: toml.SchemaFormat}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		fill(&info, bi)
+	}
+	if info.Version == "" {
+		info.Version = "devel"
+	}
+	return info
+}
+
+// fill completes info from the embedded build information without
+// overriding values set through -ldflags.
+func fill(info *Info, bi *debug.BuildInfo) {
+	info.GoVersion = bi.GoVersion
+	if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			info.CommitTime = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+}
+
+// String returns a one-line summary, e.g.
+// "smf v1.2.0 (commit abc1234, go1.26.0, schema_format 1)".
+func (i Info) String() string {
+	parts := make([]string, 0, 3)
+	if i.Commit != "" {
+		c := i.Commit
+		if len(c) > 12 {
+			c = c[:12]
+		}
+		if i.Modified {
+			c += "-dirty"
+		}
+		parts = append(parts, "commit "+c)
+	}
+	if i.GoVersion != "" {
+		parts = append(parts, i.GoVersion)
+	}
+	parts = append(parts, fmt.Sprintf("schema_format %d", i.SchemaFormat))
+	return fmt.Sprintf("smf %s (%s)", i.Version, strings.Join(parts, ", "))
+}
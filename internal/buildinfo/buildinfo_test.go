@@ -0,0 +1,53 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"smf/internal/parser/toml"
+)
+
+func TestFillFromBuildInfo(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.26.0",
+		Main:      debug.Module{Path: "smf", Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+			{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	info := Info{SchemaFormat: toml.SchemaFormat}
+	fill(&info, bi)
+
+	assert.Equal(t, Info{
+		Version:      "v1.4.0",
+		Commit:       "0123456789abcdef0123",
+		CommitTime:   "2026-01-02T03:04:05Z",
+		Modified:     true,
+		GoVersion:    "go1.26.0",
+		SchemaFormat: toml.SchemaFormat,
+	}, info)
+	assert.Equal(t, "smf v1.4.0 (commit 0123456789ab-dirty, go1.26.0, schema_format 1)", info.String())
+}
+
+func TestFillKeepsLinkerValues(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.26.0",
+		Main:      debug.Module{Path: "smf", Version: "(devel)"},
+		Settings:  []debug.BuildSetting{{Key: "vcs.revision", Value: "fromvcs"}},
+	}
+	info := Info{Version: "v2.0.0", Commit: "fromldflags"}
+	fill(&info, bi)
+	assert.Equal(t, "v2.0.0", info.Version)
+	assert.Equal(t, "fromldflags", info.Commit)
+}
+
+func TestReadDefaultsToDevel(t *testing.T) {
+	info := Read()
+	assert.Equal(t, "devel", info.Version, "test binaries carry no module version")
+	assert.Equal(t, toml.SchemaFormat, info.SchemaFormat)
+	assert.NotEmpty(t, info.GoVersion)
+}
@@ -0,0 +1,197 @@
+// Package check parses a TOML schema buffer and reports every problem found
+// as a list of positioned diagnostics, for editor integrations and CI.
+// Decode errors, unknown keys, validation failures, unresolved references
+// and lint findings all come back through a single call to Run.
+package check
+
+import (
+	"bytes"
+	"errors"
+
+	"smf/internal/core"
+	"smf/internal/parser/toml"
+)
+
+// Severity is the importance of a diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Code is a stable diagnostic identifier such as "SMF010". Codes are never
+// reused or renumbered so editors can map them to quick-fixes.
+type Code string
+
+// Diagnostic codes. Keep docs/docs/commands/check.md in sync.
+const (
+	CodeSyntax              Code = "SMF001"
+	CodeSchemaFormat        Code = "SMF002"
+	CodeUnknownKey          Code = "SMF010"
+	CodeDeprecatedSyntax    Code = "SMF011"
+	CodeStrayDialectOptions Code = "SMF012"
+	CodeUnsupportedFeature  Code = "SMF013"
+	CodeDialectUnsupported  Code = "SMF014"
+	CodeMissingExtension    Code = "SMF015"
+	CodeExcludedReference   Code = "SMF016"
+	CodeRowSizeLimit        Code = "SMF017"
+	CodeUnindexedForeignKey Code = "SMF018"
+	CodeNamingConvention    Code = "SMF019"
+	CodeInvalidSchema       Code = "SMF020"
+	CodeUnresolvedReference Code = "SMF021"
+	CodeMissingPrimaryKey   Code = "SMF022"
+	CodeImplicitCollation   Code = "SMF023"
+	CodeUnknownType         Code = "SMF024"
+)
+
+// codeNames holds the short name reported next to every code.
+var codeNames = map[Code]string{
+	CodeSyntax:              "syntax-error",
+	CodeSchemaFormat:        "unsupported-schema-format",
+	CodeUnknownKey:          string(core.WarningUnknownKey),
+	CodeDeprecatedSyntax:    string(core.WarningDeprecatedSyntax),
+	CodeStrayDialectOptions: string(core.WarningStrayDialectOptions),
+	CodeUnsupportedFeature:  string(core.WarningUnsupportedFeature),
+	CodeDialectUnsupported:  string(core.WarningDialectUnsupported),
+	CodeMissingExtension:    string(core.WarningMissingExtension),
+	CodeExcludedReference:   string(core.WarningExcludedReference),
+	CodeRowSizeLimit:        string(core.WarningRowSizeLimit),
+	CodeUnindexedForeignKey: string(core.WarningUnindexedForeignKey),
+	CodeNamingConvention:    string(core.WarningNamingConvention),
+	CodeInvalidSchema:       "invalid-schema",
+	CodeUnresolvedReference: "unresolved-reference",
+	CodeMissingPrimaryKey:   string(core.WarningMissingPrimaryKey),
+	CodeImplicitCollation:   string(core.WarningImplicitCollation),
+	CodeUnknownType:         string(core.WarningUnknownType),
+}
+
+// warningCodes maps core warning codes to diagnostic codes. Run drops
+// warnings missing here, so every core.WarningCode must be listed;
+// TestEveryWarningCodeIsMapped checks it.
+var warningCodes = map[core.WarningCode]Code{
+	core.WarningUnknownKey:          CodeUnknownKey,
+	core.WarningDeprecatedSyntax:    CodeDeprecatedSyntax,
+	core.WarningStrayDialectOptions: CodeStrayDialectOptions,
+	core.WarningUnsupportedFeature:  CodeUnsupportedFeature,
+	core.WarningDialectUnsupported:  CodeDialectUnsupported,
+	core.WarningMissingExtension:    CodeMissingExtension,
+	core.WarningExcludedReference:   CodeExcludedReference,
+	core.WarningRowSizeLimit:        CodeRowSizeLimit,
+	core.WarningUnindexedForeignKey: CodeUnindexedForeignKey,
+	core.WarningNamingConvention:    CodeNamingConvention,
+	core.WarningMissingPrimaryKey:   CodeMissingPrimaryKey,
+	core.WarningImplicitCollation:   CodeImplicitCollation,
+	core.WarningUnknownType:         CodeUnknownType,
+}
+
+// Name returns the short name of the code, e.g. "unknown-key".
+func (c Code) Name() string {
+	return codeNames[c]
+}
+
+// Diagnostic is a single problem at a position in the source.
+type Diagnostic struct {
+	// File is the name the source was read from (Options.File).
+	File string `json:"file"`
+	// Line is the 1-based line of the problem; 0 when it has no position.
+	Line int `json:"line"`
+	// Col is the 1-based column of the problem; 1 when only the line is known.
+	Col      int      `json:"col"`
+	Severity Severity `json:"severity"`
+	Code     Code     `json:"code"`
+	// Name is the short name of Code, e.g. "unknown-key".
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// Options configures Run.
+type Options struct {
+	// File is reported in every diagnostic; it is not read.
+	File string
+}
+
+// Run parses src as a TOML schema and returns all diagnostics in source
+// order. db.Validate stops at its first failure, so at most one validation
+// diagnostic is reported per run next to all decode, key and lint findings.
+func Run(src []byte, opts Options) []Diagnostic {
+	p := toml.NewParser()
+	_, err := p.Parse(bytes.NewReader(src))
+
+	var diags []Diagnostic
+	for _, e := range leafErrors(err) {
+		diags = append(diags, errorDiagnostic(opts.File, e))
+	}
+	for _, w := range p.Warnings() {
+		code, ok := warningCodes[w.Code]
+		if !ok {
+			continue
+		}
+		diags = append(diags, newDiagnostic(opts.File, w.Line, 0, SeverityWarning, code, w.Message))
+	}
+	sortDiagnostics(diags)
+	return diags
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func errorDiagnostic(file string, err error) Diagnostic {
+	var line, col int
+	msg := err.Error()
+	var pe *toml.ParseError
+	if errors.As(err, &pe) {
+		line, col, msg = pe.Line, pe.Column, pe.Err.Error()
+	}
+	return newDiagnostic(file, line, col, SeverityError, errorCode(err), msg)
+}
+
+func errorCode(err error) Code {
+	var (
+		we  *core.WarningError
+		re  *core.ReferenceError
+		sfe *toml.SchemaFormatError
+	)
+	switch {
+	case errors.Is(err, toml.ErrDecode):
+		return CodeSyntax
+	case errors.As(err, &sfe):
+		return CodeSchemaFormat
+	case errors.As(err, &we):
+		if code, ok := warningCodes[we.Warning.Code]; ok {
+			return code
+		}
+	case errors.As(err, &re):
+		return CodeUnresolvedReference
+	}
+	return CodeInvalidSchema
+}
+
+func newDiagnostic(file string, line, col int, sev Severity, code Code, msg string) Diagnostic {
+	if line > 0 && col == 0 {
+		col = 1
+	}
+	return Diagnostic{File: file, Line: line, Col: col, Severity: sev, Code: code, Name: code.Name(), Message: msg}
+}
+
+// leafErrors flattens errors.Join trees into their individual errors.
+func leafErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var out []error
+		for _, e := range joined.Unwrap() {
+			out = append(out, leafErrors(e)...)
+		}
+		return out
+	}
+	return []error{err}
+}
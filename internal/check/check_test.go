@@ -0,0 +1,296 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+const header = `[database]
+name    = "testdb"
+dialect = "mysql"
+`
+
+func TestRunCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		want     Code
+		severity Severity
+		line     int
+		col      int
+	}{
+		{
+			name:     "syntax error",
+			src:      header + "\n[[tables]]\nname = = \"x\"\n",
+			want:     CodeSyntax,
+			severity: SeverityError,
+			line:     6,
+		},
+		{
+			name:     "schema format",
+			src:      "schema_format = 7\n" + header,
+			want:     CodeSchemaFormat,
+			severity: SeverityError,
+			line:     1,
+			col:      1,
+		},
+		{
+			name: "unknown key",
+			src: header + `
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+  nullabe     = true
+`,
+			want:     CodeUnknownKey,
+			severity: SeverityWarning,
+			line:     12,
+			col:      1,
+		},
+		{
+			name: "strict unknown key",
+			src: header + `
+[validation]
+strict_keys = true
+
+[[tables]]
+name = "users"
+owner = "me"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+`,
+			want:     CodeUnknownKey,
+			severity: SeverityError,
+			line:     10,
+			col:      1,
+		},
+		{
+			name: "deprecated syntax",
+			src: header + `
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name = "plan"
+  type = "enum('free','pro')"
+`,
+			want:     CodeDeprecatedSyntax,
+			severity: SeverityWarning,
+			line:     10,
+			col:      1,
+		},
+		{
+			name: "stray dialect options",
+			src: header + `
+[[tables]]
+name = "users"
+
+  [tables.options.postgresql]
+  unlogged = true
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+`,
+			want:     CodeStrayDialectOptions,
+			severity: SeverityWarning,
+			line:     8,
+			col:      1,
+		},
+		{
+			name: "invalid schema",
+			src: header + `
+[[tables]]
+name = "Users"
+
+  [[tables.columns]]
+  name = "id"
+  type = "int"
+`,
+			want:     CodeInvalidSchema,
+			severity: SeverityError,
+			line:     5,
+			col:      1,
+		},
+		{
+			name: "unresolved reference",
+			src: header + `
+[[tables]]
+name = "orders"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name       = "user_id"
+  type       = "int"
+  references = "users.id"
+`,
+			want:     CodeUnresolvedReference,
+			severity: SeverityError,
+			line:     5,
+			col:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := Run([]byte(tt.src), Options{File: "schema.toml"})
+			require.Len(t, diags, 1, "%v", diags)
+			d := diags[0]
+			assert.Equal(t, tt.want, d.Code)
+			assert.Equal(t, tt.want.Name(), d.Name)
+			assert.Equal(t, tt.severity, d.Severity)
+			assert.Equal(t, tt.line, d.Line)
+			if tt.col > 0 {
+				assert.Equal(t, tt.col, d.Col)
+			} else {
+				assert.Positive(t, d.Col)
+			}
+			assert.Equal(t, "schema.toml", d.File)
+			assert.NotEmpty(t, d.Message)
+		})
+	}
+}
+
+func TestRunAggregatesAndSorts(t *testing.T) {
+	src := header + `
+[[tables]]
+name = "users"
+owner = "me"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+  nullabe     = true
+
+  [[tables.columns]]
+  name = "plan"
+  type = "enum('free','pro')"
+`
+	diags := Run([]byte(src), Options{})
+	var got []Code
+	var lines []int
+	for _, d := range diags {
+		got = append(got, d.Code)
+		lines = append(lines, d.Line)
+	}
+	assert.Equal(t, []Code{CodeUnknownKey, CodeUnknownKey, CodeDeprecatedSyntax}, got)
+	assert.Equal(t, []int{7, 13, 17}, lines)
+	assert.False(t, HasErrors(diags))
+}
+
+func TestRunCleanSchema(t *testing.T) {
+	src := header + `
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+`
+	assert.Empty(t, Run([]byte(src), Options{}))
+}
+
+// TestCodesAreStable pins every code to its name. Changing an entry breaks
+// editor integrations; add new codes instead.
+func TestCodesAreStable(t *testing.T) {
+	assert.Equal(t, map[Code]string{
+		"SMF001": "syntax-error",
+		"SMF002": "unsupported-schema-format",
+		"SMF010": "unknown-key",
+		"SMF011": "deprecated-syntax",
+		"SMF012": "stray-dialect-options",
+		"SMF013": "unsupported-feature",
+		"SMF014": "dialect-unsupported",
+		"SMF015": "missing-extension",
+		"SMF016": "excluded-reference",
+		"SMF017": "row-size-limit",
+		"SMF018": "unindexed-foreign-key",
+		"SMF019": "naming-convention",
+		"SMF020": "invalid-schema",
+		"SMF021": "unresolved-reference",
+		"SMF022": "missing-primary-key",
+		"SMF023": "implicit-collation",
+		"SMF024": "unknown-type",
+	}, codeNames)
+
+	for wc, code := range warningCodes {
+		assert.Equal(t, string(wc), code.Name(), "warning code %s", wc)
+	}
+}
+
+// TestEveryWarningCodeIsMapped reads the WarningCode constants from
+// core/warning.go, since Run drops warnings whose code has no diagnostic code.
+func TestEveryWarningCodeIsMapped(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(filepath.Dir(filename), "..", "core", "warning.go"), nil, 0)
+	require.NoError(t, err)
+
+	var codes []core.WarningCode
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if id, ok := vs.Type.(*ast.Ident); !ok || id.Name != "WarningCode" {
+				continue
+			}
+			for _, v := range vs.Values {
+				code, err := strconv.Unquote(v.(*ast.BasicLit).Value)
+				require.NoError(t, err)
+				codes = append(codes, core.WarningCode(code))
+			}
+		}
+	}
+	require.NotEmpty(t, codes)
+	for _, wc := range codes {
+		assert.Contains(t, warningCodes, wc, "core.WarningCode %q has no diagnostic code", wc)
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	diags := []Diagnostic{newDiagnostic("s.toml", 3, 0, SeverityError, CodeInvalidSchema, "boom")}
+
+	var text bytes.Buffer
+	require.NoError(t, WriteText(&text, diags))
+	assert.Equal(t, "s.toml:3:1: error: SMF020 invalid-schema: boom\n", text.String())
+
+	var js bytes.Buffer
+	require.NoError(t, WriteJSON(&js, diags))
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(js.Bytes(), &decoded))
+	assert.Equal(t, map[string]any{
+		"file": "s.toml", "line": 3.0, "col": 1.0, "severity": "error",
+		"code": "SMF020", "name": "invalid-schema", "message": "boom",
+	}, decoded[0])
+
+	js.Reset()
+	require.NoError(t, WriteJSON(&js, nil))
+	assert.Equal(t, "[]\n", js.String())
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/check"
+)
+
+func checkCmd() *cobra.Command {
+	var (
+		stdin  bool
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check [schema.toml]",
+		Short: "Report every problem in a schema with its position",
+		Long: "Parse a TOML schema and print all diagnostics (decode errors, unknown keys, validation " +
+			"failures, unresolved references and lint findings) with file, line, column and a stable " +
+			"SMFnnn code. With --stdin the schema is read from standard input and the optional " +
+			"argument only names the file in the output. Exits non-zero when any error is found.",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeTOMLFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := "<stdin>"
+			if len(args) == 1 {
+				name = args[0]
+			}
+			var (
+				src []byte
+				err error
+			)
+			switch {
+			case stdin:
+				src, err = io.ReadAll(cmd.InOrStdin())
+			case len(args) == 1:
+				src, err = os.ReadFile(args[0])
+			default:
+				return errors.New("a schema file or --stdin is required")
+			}
+			if err != nil {
+				return err
+			}
+
+			diags := check.Run(src, check.Options{File: name})
+			switch format {
+			case "text":
+				err = check.WriteText(cmd.OutOrStdout(), diags)
+			case "json":
+				err = check.WriteJSON(cmd.OutOrStdout(), diags)
+			default:
+				return fmt.Errorf("unsupported format %q; use text or json", format)
+			}
+			if err != nil {
+				return err
+			}
+			if check.HasErrors(diags) {
+				cmd.SilenceUsage = true
+				return errors.New("schema has errors")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&stdin, "stdin", false, "Read the schema from standard input")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format: text or json")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("text", "json"))
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	schema "smf/internal/parser"
+)
+
+// completeSchemaFile completes the schema file argument of a command to files
+// with a supported extension.
+func completeSchemaFile(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return schema.Formats(), cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completeTOMLFile is completeSchemaFile for commands that only read TOML.
+func completeTOMLFile(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{schema.FormatTOML}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completeValues completes a flag to the values matching the typed prefix.
+func completeValues[T ~string](values ...T) cobra.CompletionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var matches []string
+		for _, v := range values {
+			if strings.HasPrefix(string(v), toComplete) {
+				matches = append(matches, string(v))
+			}
+		}
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// complete runs cobra's hidden __complete command and returns the
+// suggestions and the directive line.
+func complete(t *testing.T, args ...string) ([]string, string) {
+	t.Helper()
+	root := NewRootCmd()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	require.NoError(t, root.Execute())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.NotEmpty(t, lines)
+	return lines[:len(lines)-1], lines[len(lines)-1]
+}
+
+func TestCompleteFlagValues(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "docs format", args: []string{"docs", "--format", ""}, want: []string{"markdown", "html"}},
+		{name: "check format", args: []string{"check", "--format", ""}, want: []string{"text", "json"}},
+		{name: "gen go nullable", args: []string{"gen", "go", "--nullable", ""}, want: []string{"pointer", "sql"}},
+		{name: "from-format", args: []string{"fingerprint", "--from-format", ""}, want: []string{"toml", "prisma"}},
+		{name: "prefix", args: []string{"docs", "--format", "h"}, want: []string{"html"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, directive := complete(t, tt.args...)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, ":4", directive, "ShellCompDirectiveNoFileComp")
+		})
+	}
+}
+
+func TestCompleteSchemaFileArgument(t *testing.T) {
+	got, directive := complete(t, "fingerprint", "")
+	assert.Equal(t, []string{"toml", "prisma"}, got)
+	assert.Equal(t, ":8", directive, "ShellCompDirectiveFilterFileExt")
+
+	got, _ = complete(t, "upgrade-schema", "")
+	assert.Equal(t, []string{"toml"}, got)
+
+	got, directive = complete(t, "fingerprint", "schema.toml", "")
+	assert.Empty(t, got)
+	assert.Equal(t, ":4", directive)
+}
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			root := NewRootCmd()
+			var out bytes.Buffer
+			root.SetOut(&out)
+			root.SetArgs([]string{"completion", shell})
+			require.NoError(t, root.Execute())
+			assert.Contains(t, out.String(), "smf")
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/docs"
+)
+
+func docsCmd() *cobra.Command {
+	var (
+		outDir string
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "docs <schema.toml>",
+		Short: "Generate schema documentation",
+		Long: "Generate one page per table (columns, constraints, indexes, foreign keys and enum values) " +
+			"plus an index page grouping tables by foreign-key relationships.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSchemaFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := parseSchema(args[0], cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			pages, err := docs.Generate(db, docs.Format(format))
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return err
+			}
+			for _, p := range pages {
+				if err := os.WriteFile(filepath.Join(outDir, p.Name), p.Content, 0o644); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "wrote %d pages to %s\n", len(pages), outDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outDir, "output", "o", "docs", "Directory to write the pages to")
+	cmd.Flags().StringVarP(&format, "format", "f", string(docs.FormatMarkdown), "Output format: markdown or html")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues(docs.Formats()...))
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/core"
+)
+
+func fingerprintCmd() *cobra.Command {
+	var opts core.FingerprintOptions
+
+	cmd := &cobra.Command{
+		Use:   "fingerprint <schema.toml>",
+		Short: "Print a stable content hash of a schema",
+		Long: "Parse the schema and print a sha256 hash of its normalized form. The hash does not " +
+			"depend on the order of tables, constraints or indexes, and ignores comments unless " +
+			"--include-comments is set.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSchemaFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := parseSchema(args[0], cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			fp, err := db.Fingerprint(opts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), fp)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.IncludeComments, "include-comments", false, "Include table, column and index comments in the hash")
+
+	return cmd
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/gen"
+	"smf/internal/gen/golang"
+	_ "smf/internal/gen/prisma"
+	_ "smf/internal/gen/sqlalchemy"
+)
+
+func genCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate code from a schema",
+	}
+
+	cmd.AddCommand(genGoCmd())
+	cmd.AddCommand(genExportCmd("prisma", "Generate a Prisma schema (schema.prisma)"))
+	cmd.AddCommand(genExportCmd("sqlalchemy", "Generate SQLAlchemy models (models.py)"))
+
+	return cmd
+}
+
+func genGoCmd() *cobra.Command {
+	var (
+		opts         golang.Options
+		outDir       string
+		nullable     string
+		templatePath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "go <schema.toml>",
+		Short: "Generate Go model structs",
+		Long: "Generate one Go file per table containing a struct with db and json tags, " +
+			"plus a named type and constants for every enum column.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSchemaFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := parseSchemaForTarget(args[0], cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			opts.Nullable = golang.NullableStyle(nullable)
+			if templatePath != "" {
+				data, err := os.ReadFile(templatePath)
+				if err != nil {
+					return err
+				}
+				opts.Template = string(data)
+			}
+
+			files, err := golang.Generate(db, opts)
+			if err != nil {
+				return err
+			}
+			if err := writeFiles(outDir, files); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "wrote %d files to %s\n", len(files), outDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outDir, "output", "o", "models", "Directory to write the files to")
+	cmd.Flags().StringVarP(&opts.Package, "package", "p", "models", "Package name of the generated files")
+	cmd.Flags().StringVar(&nullable, "nullable", string(golang.NullablePointer), "Nullable column representation: pointer or sql")
+	_ = cmd.RegisterFlagCompletionFunc("nullable", completeValues(golang.NullablePointer, golang.NullableSQL))
+	cmd.Flags().StringVar(&templatePath, "template", "", "Template file replacing the built-in one (must define \"file\")")
+
+	return cmd
+}
+
+// genExportCmd builds the subcommand for a registered gen.SchemaExporter.
+func genExportCmd(name, short string) *cobra.Command {
+	var outDir string
+
+	cmd := &cobra.Command{
+		Use:               name + " <schema.toml>",
+		Short:             short,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSchemaFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exporter, err := gen.NewExporter(name)
+			if err != nil {
+				return err
+			}
+			db, err := parseSchemaForTarget(args[0], cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			files, err := exporter.Export(db)
+			if err != nil {
+				return err
+			}
+			if err := writeFiles(outDir, files); err != nil {
+				return err
+			}
+			for _, f := range files {
+				fmt.Fprintf(cmd.ErrOrStderr(), "wrote %s\n", filepath.Join(outDir, f.Name))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outDir, "output", "o", ".", "Directory to write the files to")
+
+	return cmd
+}
+
+// writeFiles writes generated files into dir, creating it if needed.
+func writeFiles(dir string, files []gen.File) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Name), f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
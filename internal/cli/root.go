@@ -0,0 +1,32 @@
+// Package cli builds the smf command tree. cmd/smf only executes it, so
+// every binary and test shares the same commands and flags.
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"smf/internal/buildinfo"
+	schema "smf/internal/parser"
+)
+
+// NewRootCmd builds the smf command tree.
+func NewRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:     "smf",
+		Short:   "Schema migration framework – TOML-first database schema tool",
+		Version: buildinfo.Read().Version,
+	}
+	rootCmd.PersistentFlags().StringVar(&fromFormat, "from-format", "", fromFormatUsage())
+	_ = rootCmd.RegisterFlagCompletionFunc("from-format", completeValues(schema.Formats()...))
+
+	// rootCmd.AddCommand(migrationCmd())
+	rootCmd.AddCommand(checkCmd())
+	rootCmd.AddCommand(docsCmd())
+	rootCmd.AddCommand(fingerprintCmd())
+	rootCmd.AddCommand(genCmd())
+	rootCmd.AddCommand(testdataCmd())
+	rootCmd.AddCommand(upgradeSchemaCmd())
+	rootCmd.AddCommand(versionCmd())
+
+	return rootCmd
+}
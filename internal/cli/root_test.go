@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// flagSpec pins the user-facing shape of a flag.
+type flagSpec struct {
+	shorthand string
+	def       string
+}
+
+// TestFlags pins every flag of every command. Renaming a flag, changing its
+// shorthand or default breaks scripts; update this table deliberately.
+func TestFlags(t *testing.T) {
+	want := map[string]flagSpec{
+		"smf --from-format":                  {def: ""},
+		"smf check --format":                 {shorthand: "f", def: "text"},
+		"smf check --stdin":                  {def: "false"},
+		"smf docs --format":                  {shorthand: "f", def: "markdown"},
+		"smf docs --output":                  {shorthand: "o", def: "docs"},
+		"smf fingerprint --include-comments": {def: "false"},
+		"smf gen go --nullable":              {def: "pointer"},
+		"smf gen go --output":                {shorthand: "o", def: "models"},
+		"smf gen go --package":               {shorthand: "p", def: "models"},
+		"smf gen go --template":              {def: ""},
+		"smf gen prisma --output":            {shorthand: "o", def: "."},
+		"smf gen sqlalchemy --output":        {shorthand: "o", def: "."},
+		"smf testdata --batch-size":          {def: "500"},
+		"smf testdata --default-rows":        {def: "10"},
+		"smf testdata --format":              {shorthand: "f", def: "sql"},
+		"smf testdata --output":              {shorthand: "o", def: ""},
+		"smf testdata --rows":                {def: "[]"},
+		"smf testdata --seed":                {def: "0"},
+		"smf upgrade-schema --dry-run":       {shorthand: "d", def: "false"},
+		"smf version --format":               {shorthand: "f", def: "text"},
+	}
+
+	got := make(map[string]flagSpec)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		add := func(f *pflag.Flag) {
+			if f.Name == "help" {
+				return
+			}
+			got[cmd.CommandPath()+" --"+f.Name] = flagSpec{shorthand: f.Shorthand, def: f.DefValue}
+		}
+		cmd.LocalNonPersistentFlags().VisitAll(add)
+		cmd.PersistentFlags().VisitAll(add)
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(NewRootCmd())
+
+	assert.Equal(t, want, got)
+}
+
+func TestCommands(t *testing.T) {
+	var names []string
+	for _, cmd := range NewRootCmd().Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Equal(t, []string{"check", "docs", "fingerprint", "gen", "testdata", "upgrade-schema", "version"}, names)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"smf/internal/core"
+	schema "smf/internal/parser"
+)
+
+// fromFormat is the value of the persistent --from-format flag.
+var fromFormat string
+
+func fromFormatUsage() string {
+	return "Schema source format (" + strings.Join(schema.Formats(), ", ") + "); detected from the file extension when empty"
+}
+
+// parseSchema parses a schema file argument, honoring --from-format. The
+// parser's warnings are written to w.
+func parseSchema(path string, w io.Writer) (*core.Database, error) {
+	db, warnings, err := schema.ParseFileFormat(path, fromFormat)
+	if err != nil {
+		return nil, err
+	}
+	for _, warn := range warnings {
+		if warn.Line > 0 {
+			fmt.Fprintf(w, "%s:%d: warning: %s\n", path, warn.Line, warn)
+		} else {
+			fmt.Fprintf(w, "%s: warning: %s\n", path, warn)
+		}
+	}
+	return db, nil
+}
+
+// parseSchemaForTarget parses a schema file for generation: tables, columns
+// and indexes limited to other dialects than the declared one are dropped,
+// with a note for each of them written to w after the parser's warnings.
+func parseSchemaForTarget(path string, w io.Writer) (*core.Database, error) {
+	db, err := parseSchema(path, w)
+	if err != nil || db.Dialect == nil {
+		return db, err
+	}
+	db, notes := db.ForDialect(*db.Dialect)
+	for _, n := range notes {
+		fmt.Fprintf(w, "note: %s\n", n)
+	}
+	return db, nil
+}
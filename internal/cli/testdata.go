@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/fakedata"
+)
+
+func testdataCmd() *cobra.Command {
+	var (
+		opts      fakedata.Options
+		format    string
+		output    string
+		batchSize int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "testdata <schema.toml>",
+		Short: "Generate fake rows for a schema",
+		Long: "Generate referentially consistent fake rows for every table: foreign keys point at " +
+			"generated parent rows, unique keys stay distinct, enum and set columns use their values " +
+			"and simple CHECK comparisons are respected. The same --seed always gives the same rows. " +
+			"SQL output is written to standard output unless --output names a file; CSV output " +
+			"writes one <table>.csv per table into the --output directory.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSchemaFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "sql" && format != "csv" {
+				return fmt.Errorf("unsupported format %q; use sql or csv", format)
+			}
+			db, err := parseSchemaForTarget(args[0], cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			tables, err := fakedata.Generate(db, opts)
+			if err != nil {
+				return err
+			}
+
+			if format == "csv" {
+				files, err := fakedata.CSVFiles(tables)
+				if err != nil {
+					return err
+				}
+				dir := output
+				if dir == "" {
+					dir = "."
+				}
+				if err := writeFiles(dir, files); err != nil {
+					return err
+				}
+				for _, f := range files {
+					fmt.Fprintf(cmd.ErrOrStderr(), "wrote %s\n", filepath.Join(dir, f.Name))
+				}
+				return nil
+			}
+
+			if output == "" {
+				return fakedata.WriteSQL(cmd.OutOrStdout(), tables, db, batchSize)
+			}
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			if err := fakedata.WriteSQL(f, tables, db, batchSize); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "wrote %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringToIntVar(&opts.Rows, "rows", nil, "Rows per table, e.g. users=1000,orders=5000")
+	cmd.Flags().IntVar(&opts.DefaultRows, "default-rows", fakedata.DefaultRows, "Rows for tables not listed in --rows")
+	cmd.Flags().Uint64Var(&opts.Seed, "seed", 0, "Seed for the generated values")
+	cmd.Flags().StringVarP(&format, "format", "f", "sql", "Output format: sql or csv")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("sql", "csv"))
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write SQL to, or directory for CSV files (default: standard output or .)")
+	cmd.Flags().IntVar(&batchSize, "batch-size", fakedata.DefaultBatchSize, "Rows per INSERT statement")
+
+	return cmd
+}
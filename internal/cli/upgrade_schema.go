@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/parser/toml"
+)
+
+func upgradeSchemaCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade-schema <schema.toml>",
+		Short: "Rewrite a schema file to the current TOML format",
+		Long: "Rewrite deprecated constructs (such as enum values embedded in the type string) " +
+			"to their current spelling and stamp the file with the current schema_format. " +
+			"Comments and layout are preserved.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeTOMLFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			out, changes, err := toml.Upgrade(data, path)
+			if err != nil {
+				return err
+			}
+
+			for _, c := range changes {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", path, c)
+			}
+			if dryRun {
+				_, err := cmd.OutOrStdout().Write(out)
+				return err
+			}
+			if len(changes) == 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s is already up to date\n", path)
+				return nil
+			}
+			return os.WriteFile(path, out, info.Mode().Perm())
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Print the upgraded schema instead of rewriting the file")
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"smf/internal/buildinfo"
+)
+
+func versionCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the smf version and build information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info := buildinfo.Read()
+			switch format {
+			case "text":
+				fmt.Fprintln(cmd.OutOrStdout(), info)
+				return nil
+			case "json":
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(info)
+			default:
+				return fmt.Errorf("unsupported format %q; use text or json", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format: text or json")
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues("text", "json"))
+
+	return cmd
+}
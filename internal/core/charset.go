@@ -0,0 +1,154 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// mysqlCharsets are the character sets of MySQL and MariaDB. Every collation
+// name starts with the name of its character set and an underscore, except
+// the single collation of binary, which is named binary.
+var mysqlCharsets = []string{
+	"armscii8", "ascii", "big5", "binary", "cp1250", "cp1251", "cp1256", "cp1257",
+	"cp850", "cp852", "cp866", "cp932", "dec8", "eucjpms", "euckr", "gb18030",
+	"gb2312", "gbk", "geostd8", "greek", "hebrew", "hp8", "keybcs2", "koi8r",
+	"koi8u", "latin1", "latin2", "latin5", "latin7", "macce", "macroman", "sjis",
+	"swe7", "tis620", "ucs2", "ujis", "utf16", "utf16le", "utf32", "utf8mb3",
+	"utf8mb4",
+}
+
+// charsetAliases maps alternative character set names to the name their
+// collations use as well.
+var charsetAliases = map[string]string{"utf8": "utf8mb3"}
+
+// mariadbUCACharsets accept the MariaDB uca1400_* collations, whose names do
+// not start with a character set.
+var mariadbUCACharsets = []string{"ucs2", "utf16", "utf32", "utf8mb3", "utf8mb4"}
+
+// canonicalCharset returns the lower-cased name of a known character set, or
+// false when the name is not known.
+func canonicalCharset(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := charsetAliases[name]; ok {
+		name = alias
+	}
+	return name, slices.Contains(mysqlCharsets, name)
+}
+
+// collationCharset returns the character set a collation belongs to, or
+// false when the collation does not name a known one.
+func collationCharset(collation string) (string, bool) {
+	collation = strings.ToLower(collation)
+	if collation == "binary" {
+		return "binary", true
+	}
+	prefix, _, ok := strings.Cut(collation, "_")
+	if !ok {
+		return "", false
+	}
+	return canonicalCharset(prefix)
+}
+
+// collationFits reports whether collation can be used with charset in d.
+// Pairs involving an unknown character set or collation are accepted.
+func collationFits(charset, collation string, d Dialect) bool {
+	cs, ok := canonicalCharset(charset)
+	if !ok {
+		return true
+	}
+	if d == DialectMariaDB && strings.HasPrefix(strings.ToLower(collation), "uca1400_") {
+		return slices.Contains(mariadbUCACharsets, cs)
+	}
+	owner, ok := collationCharset(collation)
+	return !ok || owner == cs
+}
+
+// collationHint suggests the collations that fit charset.
+func collationHint(charset string) string {
+	if cs, _ := canonicalCharset(charset); cs == "binary" {
+		return `use collate "binary"`
+	}
+	return fmt.Sprintf("use a collation starting with %q", strings.ToLower(charset)+"_")
+}
+
+// mysqlDefaults returns the table default character set and collation.
+func (t *Table) mysqlDefaults() (charset, collate string) {
+	if o := t.Options.MySQL; o != nil {
+		return o.Charset, o.Collate
+	}
+	return "", ""
+}
+
+// mysqlCharset returns the character set a column is stored in, from its
+// own charset or collation or else the table defaults, or "" when none is
+// set.
+func (t *Table) mysqlCharset(c *Column) string {
+	tableCharset, tableCollate := t.mysqlDefaults()
+	charset := cmp.Or(c.Charset, tableCharset)
+	if c.Charset == "" && c.Collate != "" {
+		charset, _ = collationCharset(c.Collate)
+	}
+	if charset == "" && tableCollate != "" {
+		charset, _ = collationCharset(tableCollate)
+	}
+	return strings.ToLower(charset)
+}
+
+// validateCharsets rejects table and column charset and collate pairs MySQL
+// and MariaDB refuse, such as charset = "latin1" with collate =
+// "utf8mb4_unicode_ci".
+func (db *Database) validateCharsets() error {
+	dialect := *db.Dialect
+	if !slices.Contains(mysqlFamily, dialect) {
+		return nil
+	}
+	for _, t := range db.Tables {
+		charset, collate := t.mysqlDefaults()
+		if charset != "" && collate != "" && !collationFits(charset, collate, dialect) {
+			return fmt.Errorf("table %q: collate %q is not valid for charset %q; %s", t.Name, collate, charset, collationHint(charset))
+		}
+		for _, c := range t.Columns {
+			if c.Charset != "" && c.Collate != "" && !collationFits(c.Charset, c.Collate, dialect) {
+				return fmt.Errorf("table %q, column %q: collate %q is not valid for charset %q; %s", t.Name, c.Name, c.Collate, c.Charset, collationHint(c.Charset))
+			}
+		}
+	}
+	return nil
+}
+
+// implicitCollations flags columns that set only one of charset and collate
+// and so end up with a character set or collation other than the table
+// default without saying so.
+func (t *Table) implicitCollations(idx int, dialect Dialect) []Warning {
+	if !slices.Contains(mysqlFamily, dialect) {
+		return nil
+	}
+	tableCharset, tableCollate := t.mysqlDefaults()
+	if tableCharset == "" && tableCollate != "" {
+		tableCharset, _ = collationCharset(tableCollate)
+	}
+	var warnings []Warning
+	warn := func(i int, c *Column, key, msg string) {
+		warnings = append(warnings, Warning{
+			Code:    WarningImplicitCollation,
+			Table:   t.Name,
+			Object:  c.Name,
+			Path:    fmt.Sprintf("tables[%d].columns[%d].%s", idx, i, key),
+			Message: fmt.Sprintf("table %q, column %q: %s", t.Name, c.Name, msg),
+		})
+	}
+	for i, c := range t.Columns {
+		owner, _ := collationCharset(c.Collate)
+		switch {
+		case c.Charset != "" && c.Collate == "" && tableCollate != "" && collationFits(c.Charset, tableCollate, dialect):
+			warn(i, c, "charset", fmt.Sprintf("charset %q without collate uses the default collation of %s, not the table collation %q; set collate on the column",
+				c.Charset, strings.ToLower(c.Charset), tableCollate))
+		case owner != "" && c.Charset == "" && tableCharset != "" && !collationFits(tableCharset, c.Collate, dialect):
+			warn(i, c, "collate", fmt.Sprintf("collate %q stores the column as %s, not the table charset %q; set charset on the column",
+				c.Collate, owner, tableCharset))
+		}
+	}
+	return warnings
+}
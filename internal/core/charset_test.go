@@ -0,0 +1,117 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCharsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		opts    *MySQLTableOptions
+		col     *Column
+		wantErr string
+	}{
+		{name: "matching table pair", dialect: DialectMySQL, opts: &MySQLTableOptions{Charset: "utf8mb4", Collate: "utf8mb4_unicode_ci"}},
+		{name: "utf8 alias", dialect: DialectMySQL, opts: &MySQLTableOptions{Charset: "UTF8", Collate: "utf8mb3_general_ci"}},
+		{name: "binary", dialect: DialectMySQL, opts: &MySQLTableOptions{Charset: "binary", Collate: "binary"}},
+		{name: "unknown charset", dialect: DialectMySQL, opts: &MySQLTableOptions{Charset: "custom", Collate: "utf8mb4_bin"}},
+		{name: "mariadb uca1400", dialect: DialectMariaDB, opts: &MySQLTableOptions{Charset: "utf8mb4", Collate: "uca1400_ai_ci"}},
+		{
+			name:    "table mismatch",
+			dialect: DialectMySQL,
+			opts:    &MySQLTableOptions{Charset: "latin1", Collate: "utf8mb4_unicode_ci"},
+			wantErr: `table "articles": collate "utf8mb4_unicode_ci" is not valid for charset "latin1"; use a collation starting with "latin1_"`,
+		},
+		{
+			name:    "binary mismatch",
+			dialect: DialectMariaDB,
+			opts:    &MySQLTableOptions{Charset: "binary", Collate: "latin1_bin"},
+			wantErr: `use collate "binary"`,
+		},
+		{
+			name:    "uca1400 with a non-unicode charset",
+			dialect: DialectMariaDB,
+			opts:    &MySQLTableOptions{Charset: "latin1", Collate: "uca1400_ai_ci"},
+			wantErr: `collate "uca1400_ai_ci" is not valid for charset "latin1"`,
+		},
+		{
+			name:    "column mismatch",
+			dialect: DialectMySQL,
+			col:     &Column{Name: "title", Type: DataTypeString, Charset: "utf8mb4", Collate: "latin1_swedish_ci"},
+			wantErr: `table "articles", column "title": collate "latin1_swedish_ci" is not valid for charset "utf8mb4"`,
+		},
+		{
+			name:    "other dialects are not checked",
+			dialect: DialectPostgreSQL,
+			col:     &Column{Name: "title", Type: DataTypeString, Charset: "utf8mb4", Collate: "latin1_swedish_ci"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}}
+			if tt.col != nil {
+				columns = append(columns, tt.col)
+			}
+			db := &Database{
+				Name:    "app",
+				Dialect: new(tt.dialect),
+				Tables:  []*Table{{Name: "articles", Columns: columns, Options: TableOptions{MySQL: tt.opts}}},
+			}
+			err := db.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLintImplicitCollation(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+				{Name: "title", Type: DataTypeString, Charset: "utf8mb4"},
+				{Name: "code", Type: DataTypeString, Collate: "latin1_bin"},
+				{Name: "slug", Type: DataTypeString, Collate: "utf8mb4_general_ci"},
+				{Name: "legacy", Type: DataTypeString, Charset: "latin1"},
+				{Name: "body", Type: DataTypeString, Charset: "utf8mb4", Collate: "utf8mb4_unicode_ci"},
+			},
+			Options: TableOptions{MySQL: &MySQLTableOptions{Charset: "utf8mb4", Collate: "utf8mb4_bin"}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, WarningImplicitCollation, warnings[0].Code)
+	assert.Equal(t, "tables[0].columns[1].charset", warnings[0].Path)
+	assert.Equal(t, `table "articles", column "title": charset "utf8mb4" without collate uses the default collation of utf8mb4, not the table collation "utf8mb4_bin"; set collate on the column`, warnings[0].Message)
+	assert.Equal(t, "tables[0].columns[2].collate", warnings[1].Path)
+	assert.Equal(t, `table "articles", column "code": collate "latin1_bin" stores the column as latin1, not the table charset "utf8mb4"; set charset on the column`, warnings[1].Message)
+}
+
+func TestMySQLRowSizeCollationCharset(t *testing.T) {
+	table := &Table{
+		Name: "articles",
+		Columns: []*Column{
+			{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+			{Name: "title", Type: DataTypeString, RawType: "VARCHAR(100)"},
+			{Name: "name", Type: DataTypeString, RawType: "VARCHAR(100)", Collate: "utf8mb4_bin"},
+		},
+		Options: TableOptions{MySQL: &MySQLTableOptions{Collate: "latin1_swedish_ci"}},
+	}
+	size := table.MySQLRowSize(true)
+	require.Len(t, size.Columns, 3)
+	assert.Equal(t, 101, size.Columns[1].Bytes, "latin1 from the table collation")
+	assert.Equal(t, 402, size.Columns[2].Bytes, "utf8mb4 from the column collation")
+}
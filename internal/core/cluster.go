@@ -0,0 +1,90 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// ForeignKeyClusters groups the tables of the given databases into clusters
+// connected by foreign keys, treating references as undirected edges. Tables
+// from all databases are merged by name, so passing the old and new side of a
+// diff yields clusters over the union of both schemas.
+//
+// Each cluster is sorted by table name, and clusters are ordered by their
+// first table name. Tables without any foreign key form single-table
+// clusters. References to tables that are not declared are ignored.
+func ForeignKeyClusters(dbs ...*Database) [][]string {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(name string) string {
+		if p := parent[name]; p != name {
+			parent[name] = find(p)
+		}
+		return parent[name]
+	}
+	union := func(a, b string) {
+		if _, ok := parent[b]; !ok {
+			return
+		}
+		ra, rb := find(a), find(b)
+		if ra == rb {
+			return
+		}
+		if rb < ra {
+			ra, rb = rb, ra
+		}
+		parent[rb] = ra
+	}
+
+	for _, db := range dbs {
+		if db == nil {
+			continue
+		}
+		for _, t := range db.Tables {
+			if _, ok := parent[t.Name]; !ok {
+				parent[t.Name] = t.Name
+			}
+		}
+	}
+	for _, db := range dbs {
+		if db == nil {
+			continue
+		}
+		for _, t := range db.Tables {
+			for _, ref := range t.referencedTables() {
+				union(t.Name, ref)
+			}
+		}
+	}
+
+	groups := make(map[string][]string)
+	for name := range parent {
+		root := find(name)
+		groups[root] = append(groups[root], name)
+	}
+	clusters := make([][]string, 0, len(groups))
+	for _, members := range groups {
+		slices.Sort(members)
+		clusters = append(clusters, members)
+	}
+	slices.SortFunc(clusters, func(a, b []string) int { return strings.Compare(a[0], b[0]) })
+	return clusters
+}
+
+// referencedTables returns the tables referenced by t, from both foreign key
+// constraints and column-level references (which Validate has not yet
+// synthesized into constraints on an unvalidated table).
+func (t *Table) referencedTables() []string {
+	var refs []string
+	for _, c := range t.Constraints {
+		if c.Type == ConstraintForeignKey && c.ReferencedTable != "" {
+			refs = append(refs, c.ReferencedTable)
+		}
+	}
+	for _, col := range t.Columns {
+		if table, _, ok := ParseReferences(col.References); ok {
+			refs = append(refs, table)
+		}
+	}
+	return refs
+}
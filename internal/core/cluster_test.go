@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForeignKeyClusters(t *testing.T) {
+	old := &Database{
+		Tables: []*Table{
+			{Name: "orders", Columns: []*Column{{Name: "user_id", References: "users.id"}}},
+			{Name: "users"},
+			{Name: "audit_log"},
+		},
+	}
+	next := &Database{
+		Tables: []*Table{
+			{
+				Name: "order_items",
+				Constraints: []*Constraint{
+					{Type: ConstraintForeignKey, Columns: []string{"order_id"}, ReferencedTable: "orders", ReferencedColumns: []string{"id"}},
+				},
+			},
+			{Name: "orders"},
+			{Name: "products", Columns: []*Column{{Name: "vendor_id", References: "vendors.id"}}},
+			{Name: "order_status_history", Columns: []*Column{{Name: "order_id", References: "orders.id"}}},
+		},
+	}
+
+	assert.Equal(t, [][]string{
+		{"audit_log"},
+		{"order_items", "order_status_history", "orders", "users"},
+		{"products"},
+	}, ForeignKeyClusters(old, next))
+}
+
+func TestForeignKeyClustersEmpty(t *testing.T) {
+	assert.Empty(t, ForeignKeyClusters())
+	assert.Empty(t, ForeignKeyClusters(nil, &Database{}))
+}
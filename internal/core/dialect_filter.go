@@ -0,0 +1,225 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// appliesTo reports whether an object limited to dialects exists for d.
+// An empty list means the object exists for every dialect.
+func appliesTo(dialects []Dialect, d Dialect) bool {
+	return len(dialects) == 0 || slices.Contains(dialects, d)
+}
+
+// AppliesTo reports whether the table exists when generating for d.
+func (t *Table) AppliesTo(d Dialect) bool { return appliesTo(t.Dialects, d) }
+
+// AppliesTo reports whether the column exists when generating for d.
+func (c *Column) AppliesTo(d Dialect) bool { return appliesTo(c.Dialects, d) }
+
+// AppliesTo reports whether the index exists when generating for d.
+func (i *Index) AppliesTo(d Dialect) bool { return appliesTo(i.Dialects, d) }
+
+// validateDialectLimits checks the dialects list of a table, column or index.
+func validateDialectLimits(dialects []Dialect) error {
+	for i, d := range dialects {
+		if !ValidDialect(string(d)) {
+			return fmt.Errorf("dialects: unknown dialect %q", d)
+		}
+		if slices.Contains(dialects[:i], d) {
+			return fmt.Errorf("dialects: duplicate dialect %q", d)
+		}
+	}
+	return nil
+}
+
+// validateDialectLimits checks the dialects lists of the table and its
+// columns and indexes. Primary key columns cannot be limited, since every
+// dialect needs the same key to identify rows.
+func (t *Table) validateDialectLimits() error {
+	if err := validateDialectLimits(t.Dialects); err != nil {
+		return err
+	}
+	for _, c := range t.Columns {
+		if err := validateDialectLimits(c.Dialects); err != nil {
+			return fmt.Errorf("column %q: %w", c.Name, err)
+		}
+		if c.PrimaryKey && len(c.Dialects) > 0 {
+			return fmt.Errorf("column %q: primary key columns cannot be limited to dialects", c.Name)
+		}
+	}
+	for _, idx := range t.Indexes {
+		if err := validateDialectLimits(idx.Dialects); err != nil {
+			return fmt.Errorf("index %q: %w", idx.Name, err)
+		}
+	}
+	return nil
+}
+
+// ForDialect returns a copy of db holding only the tables, columns and
+// indexes that exist for d, together with a note for every object left out.
+// Constraints and indexes over an omitted column, constraints (CHECK,
+// expression UNIQUE, EXCLUDE) and generated columns whose expressions read
+// one, and foreign keys to an omitted table or column, are left out as well. db itself is not modified;
+// the objects kept are shared with it.
+func (db *Database) ForDialect(d Dialect) (*Database, []string) {
+	// omitted maps the omitted tables and "table.column" pairs to the reason
+	// they are left out.
+	omitted := make(map[string]string)
+	for _, t := range db.Tables {
+		if !t.AppliesTo(d) {
+			omitted[t.Name] = "limited to " + dialectList(t.Dialects)
+			continue
+		}
+		for _, c := range t.Columns {
+			if !c.AppliesTo(d) {
+				omitted[t.Name+"."+c.Name] = "limited to " + dialectList(c.Dialects)
+			}
+		}
+		// Generated columns may read each other, so repeat until no more
+		// are left out.
+		for changed := true; changed; {
+			changed = false
+			for _, c := range t.Columns {
+				key := t.Name + "." + c.Name
+				if !c.IsGenerated || omitted[key] != "" {
+					continue
+				}
+				if name, ok := readsOmitted(t.Name, c.GenerationExpression, d, omitted); ok {
+					omitted[key] = fmt.Sprintf("its expression reads omitted column %q", name)
+					changed = true
+				}
+			}
+		}
+	}
+
+	out := *db
+	out.Tables = make([]*Table, 0, len(db.Tables))
+	var notes []string
+	for _, t := range db.Tables {
+		if reason := omitted[t.Name]; reason != "" {
+			notes = append(notes, fmt.Sprintf("table %q omitted: %s", t.Name, reason))
+			continue
+		}
+		ft, tableNotes := t.forDialect(d, omitted)
+		out.Tables = append(out.Tables, ft)
+		notes = append(notes, tableNotes...)
+	}
+	return &out, notes
+}
+
+// readsOmitted returns the first omitted column of table that expr reads.
+func readsOmitted(table, expr string, d Dialect, omitted map[string]string) (string, bool) {
+	for _, c := range ExpressionColumns(expr, d) {
+		if omitted[table+"."+c] != "" {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// expressions returns the SQL expressions a constraint evaluates: the CHECK
+// expression, the expression parts of a UNIQUE constraint and the elements of
+// an EXCLUDE constraint.
+func (con *Constraint) expressions() []string {
+	var exprs []string
+	if con.CheckExpression != "" {
+		exprs = append(exprs, con.CheckExpression)
+	}
+	exprs = append(exprs, con.Expressions...)
+	for _, e := range con.Elements {
+		exprs = append(exprs, e.Expression)
+	}
+	return exprs
+}
+
+// forDialect does the work of ForDialect for a single table that exists for
+// d. omitted holds the omitted tables and "table.column" pairs.
+func (t *Table) forDialect(d Dialect, omitted map[string]string) (*Table, []string) {
+	gone := func(table string, columns []string) bool {
+		return slices.ContainsFunc(columns, func(c string) bool { return omitted[table+"."+c] != "" })
+	}
+
+	out := *t
+	out.Columns = make([]*Column, 0, len(t.Columns))
+	out.Constraints = make([]*Constraint, 0, len(t.Constraints))
+	out.Indexes = make([]*Index, 0, len(t.Indexes))
+	var notes []string
+	for _, c := range t.Columns {
+		if reason := omitted[t.Name+"."+c.Name]; reason != "" {
+			notes = append(notes, fmt.Sprintf("table %q, column %q omitted: %s", t.Name, c.Name, reason))
+			continue
+		}
+		out.Columns = append(out.Columns, c)
+	}
+	for _, con := range t.Constraints {
+		name, reads := "", false
+		for _, expr := range con.expressions() {
+			if name, reads = readsOmitted(t.Name, expr, d, omitted); reads {
+				break
+			}
+		}
+		switch {
+		case gone(t.Name, con.Columns):
+			notes = append(notes, fmt.Sprintf("table %q, constraint %q omitted: it covers an omitted column", t.Name, con.Name))
+		case reads:
+			notes = append(notes, fmt.Sprintf("table %q, constraint %q omitted: its expression reads omitted column %q", t.Name, con.Name, name))
+		case con.Type == ConstraintForeignKey && omitted[con.ReferencedTable] != "":
+			notes = append(notes, fmt.Sprintf("table %q, constraint %q omitted: references omitted table %q", t.Name, con.Name, con.ReferencedTable))
+		case con.Type == ConstraintForeignKey && gone(con.ReferencedTable, con.ReferencedColumns):
+			notes = append(notes, fmt.Sprintf("table %q, constraint %q omitted: references an omitted column of table %q", t.Name, con.Name, con.ReferencedTable))
+		default:
+			out.Constraints = append(out.Constraints, con)
+		}
+	}
+	for _, idx := range t.Indexes {
+		columns := make([]string, len(idx.Columns))
+		for i, c := range idx.Columns {
+			columns[i] = c.Name
+		}
+		switch {
+		case !idx.AppliesTo(d):
+			notes = append(notes, fmt.Sprintf("table %q, index %q omitted: limited to %s", t.Name, idx.Name, dialectList(idx.Dialects)))
+		case gone(t.Name, columns):
+			notes = append(notes, fmt.Sprintf("table %q, index %q omitted: it covers an omitted column", t.Name, idx.Name))
+		default:
+			out.Indexes = append(out.Indexes, idx)
+		}
+	}
+	return &out, notes
+}
+
+// excludedReferences flags foreign keys of a table that exists for a target
+// dialect whose referenced table does not. Generating for that dialect drops
+// the foreign key.
+func (db *Database) excludedReferences(dialects []Dialect) []Warning {
+	var warnings []Warning
+	for i, t := range db.Tables {
+		for j, con := range t.Constraints {
+			if con.Type != ConstraintForeignKey {
+				continue
+			}
+			ref := db.FindTable(con.ReferencedTable)
+			if ref == nil {
+				continue
+			}
+			var excluded []Dialect
+			for _, d := range dialects {
+				if t.AppliesTo(d) && !ref.AppliesTo(d) {
+					excluded = append(excluded, d)
+				}
+			}
+			if len(excluded) == 0 {
+				continue
+			}
+			warnings = append(warnings, Warning{
+				Code:    WarningExcludedReference,
+				Table:   t.Name,
+				Object:  con.Name,
+				Path:    fmt.Sprintf("tables[%d].constraints[%d]", i, j),
+				Message: fmt.Sprintf("table %q, constraint %q: references table %q, which is omitted for %s", t.Name, con.Name, ref.Name, dialectList(excluded)),
+			})
+		}
+	}
+	return warnings
+}
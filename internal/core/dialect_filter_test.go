@@ -0,0 +1,207 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForDialect(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{
+			{
+				Name: "posts",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "body", Type: DataTypeString},
+					{Name: "body_tsv", Type: DataTypeString, RawType: "TEXT", Dialects: []Dialect{DialectMySQL}},
+				},
+				Constraints: []*Constraint{
+					{Name: "chk_posts_body_tsv", Type: ConstraintCheck, Columns: []string{"body_tsv"}, CheckExpression: "body_tsv <> ''"},
+				},
+				Indexes: []*Index{
+					{Name: "idx_posts_body", Columns: []ColumnIndex{{Name: "body"}}, Type: IndexTypeFullText, Dialects: []Dialect{DialectMySQL, DialectMariaDB}},
+					{Name: "idx_posts_body_tsv", Columns: []ColumnIndex{{Name: "body_tsv"}}},
+				},
+			},
+			{
+				Name:     "search_cache",
+				Dialects: []Dialect{DialectMySQL, DialectMariaDB},
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "post_id", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "fk_search_cache_posts", Type: ConstraintForeignKey, Columns: []string{"post_id"}, ReferencedTable: "posts", ReferencedColumns: []string{"id"}},
+				},
+			},
+			{
+				Name: "hits",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "cache_id", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "fk_hits_search_cache", Type: ConstraintForeignKey, Columns: []string{"cache_id"}, ReferencedTable: "search_cache", ReferencedColumns: []string{"id"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, db.Validate())
+
+	mysql, notes := db.ForDialect(DialectMySQL)
+	assert.Empty(t, notes)
+	assert.Len(t, mysql.Tables, 3)
+
+	pg, notes := db.ForDialect(DialectPostgreSQL)
+	assert.Equal(t, []string{
+		`table "posts", column "body_tsv" omitted: limited to mysql`,
+		`table "posts", constraint "chk_posts_body_tsv" omitted: it covers an omitted column`,
+		`table "posts", index "idx_posts_body" omitted: limited to mysql, mariadb`,
+		`table "posts", index "idx_posts_body_tsv" omitted: it covers an omitted column`,
+		`table "search_cache" omitted: limited to mysql, mariadb`,
+		`table "hits", constraint "fk_hits_search_cache" omitted: references omitted table "search_cache"`,
+	}, notes)
+	require.Len(t, pg.Tables, 2)
+	posts := pg.FindTable("posts")
+	assert.Nil(t, posts.FindColumn("body_tsv"))
+	assert.Empty(t, posts.Indexes)
+	assert.Nil(t, pg.FindTable("hits").FindConstraint("fk_hits_search_cache"))
+
+	assert.Len(t, db.Tables, 3, "the original database is left untouched")
+	assert.Len(t, db.FindTable("posts").Columns, 3)
+}
+
+func TestForDialectExpressions(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{{
+			Name: "products",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+				{Name: "price", Type: DataTypeFloat},
+				{Name: "list_price", Type: DataTypeFloat, Dialects: []Dialect{DialectPostgreSQL}},
+				{Name: "discount", Type: DataTypeFloat, IsGenerated: true, GenerationExpression: "list_price - price"},
+				{Name: "discount_pct", Type: DataTypeFloat, IsGenerated: true, GenerationExpression: "discount / price * 100"},
+				{Name: "price_cents", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "price * 100"},
+			},
+			Constraints: []*Constraint{
+				{Name: "chk_products_list_price", Type: ConstraintCheck, CheckExpression: "list_price >= price"},
+				{Name: "chk_products_price", Type: ConstraintCheck, CheckExpression: "price > 0"},
+				{Name: "uq_products_list_price", Type: ConstraintUnique, Expressions: []string{"round(list_price)"}},
+				{Name: "uq_products_price", Type: ConstraintUnique, Expressions: []string{"round(price)"}},
+				{Name: "ex_products_list_price", Type: ConstraintExclusion, Elements: []ExclusionElement{
+					{Expression: "id", Operator: "="},
+					{Expression: "numrange(price, list_price)", Operator: "&&"},
+				}},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	mysql, notes := db.ForDialect(DialectMySQL)
+	assert.Equal(t, []string{
+		`table "products", column "list_price" omitted: limited to postgresql`,
+		`table "products", column "discount" omitted: its expression reads omitted column "list_price"`,
+		`table "products", column "discount_pct" omitted: its expression reads omitted column "discount"`,
+		`table "products", constraint "chk_products_list_price" omitted: its expression reads omitted column "list_price"`,
+		`table "products", constraint "uq_products_list_price" omitted: its expression reads omitted column "list_price"`,
+		`table "products", constraint "ex_products_list_price" omitted: its expression reads omitted column "list_price"`,
+	}, notes)
+	products := mysql.FindTable("products")
+	assert.NotNil(t, products.FindColumn("price_cents"))
+	assert.Nil(t, products.FindColumn("discount_pct"))
+	assert.NotNil(t, products.FindConstraint("chk_products_price"))
+	assert.NotNil(t, products.FindConstraint("uq_products_price"))
+	assert.Nil(t, products.FindConstraint("ex_products_list_price"))
+}
+
+func TestValidateDialectLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		db   *Database
+		want string
+	}{
+		{
+			name: "unknown dialect",
+			db: &Database{
+				Name:    "app",
+				Dialect: new(DialectMySQL),
+				Tables: []*Table{{
+					Name:     "search_cache",
+					Dialects: []Dialect{"mysql8"},
+					Columns:  []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}},
+				}},
+			},
+			want: `table "search_cache": dialects: unknown dialect "mysql8"`,
+		},
+		{
+			name: "duplicate dialect",
+			db: &Database{
+				Name:    "app",
+				Dialect: new(DialectMySQL),
+				Tables: []*Table{{
+					Name:    "posts",
+					Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}, {Name: "body", Type: DataTypeString}},
+					Indexes: []*Index{{Name: "idx_posts_body", Columns: []ColumnIndex{{Name: "body"}}, Dialects: []Dialect{DialectMySQL, DialectMySQL}}},
+				}},
+			},
+			want: `table "posts": index "idx_posts_body": dialects: duplicate dialect "mysql"`,
+		},
+		{
+			name: "primary key column",
+			db: &Database{
+				Name:    "app",
+				Dialect: new(DialectMySQL),
+				Tables: []*Table{{
+					Name:    "posts",
+					Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true, Dialects: []Dialect{DialectMySQL}}},
+				}},
+			},
+			want: `table "posts": column "id": primary key columns cannot be limited to dialects`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintExcludedReference(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{
+			{
+				Name:     "search_cache",
+				Dialects: []Dialect{DialectMySQL, DialectMariaDB},
+				Columns:  []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}},
+			},
+			{
+				Name: "hits",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "cache_id", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "fk_hits_search_cache", Type: ConstraintForeignKey, Columns: []string{"cache_id"}, ReferencedTable: "search_cache", ReferencedColumns: []string{"id"}},
+				},
+			},
+		},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint(), "search_cache exists for mysql")
+
+	warnings := db.Lint(DialectPostgreSQL, DialectMariaDB)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningExcludedReference, warnings[0].Code)
+	assert.Equal(t, "tables[1].constraints[0]", warnings[0].Path)
+	assert.Equal(t, `table "hits", constraint "fk_hits_search_cache": references table "search_cache", which is omitted for postgresql`, warnings[0].Message)
+}
@@ -0,0 +1,359 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// exprTokenKind classifies the tokens of a SQL expression.
+type exprTokenKind int
+
+const (
+	tokenIdent        exprTokenKind = iota // unquoted identifier or keyword
+	tokenQuotedIdent                       // `name`, or [name] in SQL Server
+	tokenDoubleQuoted                      // "name": an identifier, or a string in MySQL
+	tokenString                            // 'text', including prefixed forms like N'text'
+	tokenNumber
+	tokenSymbol
+)
+
+// exprToken is one token of a SQL expression.
+type exprToken struct {
+	kind exprTokenKind
+	text string
+}
+
+// name returns the identifier the token spells, without quotes.
+func (t exprToken) name() string {
+	switch t.kind {
+	case tokenQuotedIdent, tokenDoubleQuoted:
+		return t.text[1 : len(t.text)-1]
+	default:
+		return t.text
+	}
+}
+
+// tokenizeExpression splits a SQL expression into tokens. It knows just enough
+// SQL to tell identifiers from string literals, numbers and operators, which
+// is all smf needs to find the columns an expression reads. Unterminated
+// literals run to the end of the input.
+//
+// Square brackets quote identifiers only in SQL Server, and only where a
+// name can start: after ARRAY or an identifier they open a PostgreSQL array
+// literal or subscript, whose contents are tokenized as usual.
+func tokenizeExpression(expr string, d Dialect) []exprToken {
+	var tokens []exprToken
+	for i := 0; i < len(expr); {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '\'':
+			end := quotedEnd(expr, i, '\'')
+			tokens = append(tokens, exprToken{tokenString, expr[i:end]})
+			i = end
+		case r == '"':
+			end := quotedEnd(expr, i, '"')
+			tokens = append(tokens, exprToken{tokenDoubleQuoted, expr[i:end]})
+			i = end
+		case r == '`':
+			end := quotedEnd(expr, i, '`')
+			tokens = append(tokens, exprToken{tokenQuotedIdent, expr[i:end]})
+			i = end
+		case r == '[' && d == DialectMSSQL && !followsName(tokens):
+			end := quotedEnd(expr, i, ']')
+			tokens = append(tokens, exprToken{tokenQuotedIdent, expr[i:end]})
+			i = end
+		case r >= '0' && r <= '9' || r == '.' && i+1 < len(expr) && expr[i+1] >= '0' && expr[i+1] <= '9':
+			end := i + 1
+			for end < len(expr) && (isIdentByte(expr[end]) || expr[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, exprToken{tokenNumber, expr[i:end]})
+			i = end
+		case isIdentRune(r):
+			end := i
+			for end < len(expr) {
+				r, size := utf8.DecodeRuneInString(expr[end:])
+				if !isIdentRune(r) && !unicode.IsDigit(r) && r != '$' {
+					break
+				}
+				end += size
+			}
+			if end < len(expr) && expr[end] == '\'' && isStringPrefix(expr[i:end]) {
+				end = quotedEnd(expr, end, '\'')
+				tokens = append(tokens, exprToken{tokenString, expr[i:end]})
+			} else {
+				tokens = append(tokens, exprToken{tokenIdent, expr[i:end]})
+			}
+			i = end
+		default:
+			end := i + size
+			if end < len(expr) && isTwoCharOperator(expr[i:end+1]) {
+				end++
+			}
+			tokens = append(tokens, exprToken{tokenSymbol, expr[i:end]})
+			i = end
+		}
+	}
+	return tokens
+}
+
+// quotedEnd returns the offset just past the literal opened at start, where a
+// doubled closing character is an escaped one. Backslashes escape the next
+// character of a string literal, as in MySQL; for dialects that read them
+// literally this can only make the literal longer, never split it.
+func quotedEnd(s string, start int, closing byte) int {
+	for i := start + 1; i < len(s); i++ {
+		if s[i] == '\\' && closing == '\'' {
+			i++
+			continue
+		}
+		if s[i] != closing {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == closing && closing != ']' {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(s)
+}
+
+// followsName reports whether the last token is ARRAY or an identifier that
+// is not a keyword, after which a bracket cannot start a quoted name.
+func followsName(tokens []exprToken) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	switch last.kind {
+	case tokenQuotedIdent, tokenDoubleQuoted:
+		return true
+	case tokenIdent:
+		return strings.EqualFold(last.text, "ARRAY") || !expressionKeywords[strings.ToUpper(last.text)]
+	}
+	return false
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentByte(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// isStringPrefix reports whether an identifier directly followed by a quote
+// is a literal prefix: N'..', E'..', X'..', B'..' or a MySQL character set
+// introducer such as _utf8mb4'..'.
+func isStringPrefix(ident string) bool {
+	switch strings.ToUpper(ident) {
+	case "N", "E", "X", "B":
+		return true
+	}
+	return strings.HasPrefix(ident, "_")
+}
+
+func isTwoCharOperator(s string) bool {
+	switch s {
+	case "<=", ">=", "<>", "!=", "||", "::", "->", "<<", ">>", "&&":
+		return true
+	}
+	return false
+}
+
+// expressionKeywords are the words that may appear bare in a CHECK or
+// generated column expression without naming a column.
+var expressionKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "XOR": true, "IN": true, "IS": true,
+	"NULL": true, "TRUE": true, "FALSE": true, "UNKNOWN": true,
+	"LIKE": true, "ILIKE": true, "GLOB": true, "REGEXP": true, "RLIKE": true,
+	"SIMILAR": true, "ESCAPE": true, "BETWEEN": true, "SYMMETRIC": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true,
+	"AS": true, "COLLATE": true, "DISTINCT": true, "FROM": true, "FOR": true,
+	"TO": true, "AT": true, "TIME": true, "ZONE": true, "WITH": true, "WITHOUT": true,
+	"ALL": true, "ANY": true, "SOME": true, "EXISTS": true, "DIV": true, "MOD": true,
+	"BOTH": true, "LEADING": true, "TRAILING": true, "BINARY": true, "INTERVAL": true,
+	"DATE": true, "TIMESTAMP": true, "YEAR": true, "MONTH": true, "DAY": true,
+	"HOUR": true, "MINUTE": true, "SECOND": true, "DEFAULT": true, "VALUE": true,
+	"CURRENT_DATE": true, "CURRENT_TIME": true, "CURRENT_TIMESTAMP": true,
+	"LOCALTIME": true, "LOCALTIMESTAMP": true, "CURRENT_USER": true,
+	"SESSION_USER": true, "USER": true, "SYSDATE": true, "SYSTIMESTAMP": true,
+	"ARRAY": true, "USING": true, "RETURNING": true, "OVERLAPS": true, "ON": true,
+	"JSON": true, "CURRENT_ROLE": true, "CURRENT_CATALOG": true, "CURRENT_SCHEMA": true,
+	"SYSTEM_USER": true,
+	// EXTRACT fields and interval units.
+	"EPOCH": true, "CENTURY": true, "DECADE": true, "MILLENNIUM": true, "QUARTER": true,
+	"WEEK": true, "DOW": true, "DOY": true, "ISODOW": true, "ISOYEAR": true, "JULIAN": true,
+	"MICROSECOND": true, "MICROSECONDS": true, "MILLISECOND": true, "MILLISECONDS": true,
+	"TIMEZONE": true, "TIMEZONE_HOUR": true, "TIMEZONE_MINUTE": true,
+	"YEAR_MONTH": true, "DAY_HOUR": true, "DAY_MINUTE": true, "DAY_SECOND": true,
+	"DAY_MICROSECOND": true, "HOUR_MINUTE": true, "HOUR_SECOND": true,
+	"HOUR_MICROSECOND": true, "MINUTE_SECOND": true, "MINUTE_MICROSECOND": true,
+	"SECOND_MICROSECOND": true,
+}
+
+// ExpressionColumns returns the columns a SQL expression written for d reads,
+// in order of first appearance. Keywords, function names, the collation after
+// COLLATE, the type of a CAST, CONVERT or :: conversion, of RETURNING and
+// after IS [NOT], the character set of CONVERT(... USING charset) and
+// everything inside string literals are skipped; a qualified name such as
+// t.price yields its last part. Double-quoted names are skipped too, since
+// MySQL reads them as strings.
+func ExpressionColumns(expr string, d Dialect) []string {
+	tokens := tokenizeExpression(expr, d)
+	var columns []string
+	seen := make(map[string]bool)
+	// castType is set inside CAST(... AS type), CONVERT(..., type),
+	// CONVERT(... USING charset) and after RETURNING, convType from "::" to
+	// the next symbol or keyword, which also covers types like double
+	// precision. collation is set after COLLATE, and isType after IS up to
+	// the next symbol or connective, which covers IS NOT JSON OBJECT. SQLite
+	// also compares with IS, as in a IS b, so it keeps the names after IS.
+	castType, convType, collation, isType := false, false, false, false
+	// calls holds the upper-cased function name of every open parenthesis,
+	// or "" for a plain one.
+	var calls []string
+	for i, tok := range tokens {
+		if tok.kind == tokenSymbol {
+			switch tok.text {
+			case "(":
+				call := ""
+				if i > 0 && tokens[i-1].kind == tokenIdent {
+					call = strings.ToUpper(tokens[i-1].text)
+				}
+				calls = append(calls, call)
+				// SQL Server writes the type first: CONVERT(type, expr).
+				castType = castType || d == DialectMSSQL && (call == "CONVERT" || call == "TRY_CONVERT")
+			case ")":
+				if len(calls) > 0 {
+					calls = calls[:len(calls)-1]
+				}
+				castType = false
+			case ",":
+				castType = d != DialectMSSQL && len(calls) > 0 && calls[len(calls)-1] == "CONVERT"
+			}
+			convType, collation, isType = tok.text == "::", false, false
+			continue
+		}
+		if tok.kind != tokenIdent && tok.kind != tokenQuotedIdent {
+			collation = false
+			continue
+		}
+		if collation {
+			collation = false
+			continue
+		}
+		if tok.kind == tokenIdent {
+			upper := strings.ToUpper(tok.text)
+			if isType && !typeEnds[upper] {
+				continue
+			}
+			isType = false
+			switch {
+			case upper == "AS" || upper == "USING" || upper == "RETURNING":
+				castType = true
+				continue
+			case upper == "COLLATE":
+				collation = true
+				continue
+			case upper == "IS":
+				isType = d != DialectSQLite
+				continue
+			case (upper == "ERROR" || upper == "EMPTY") && nextToON(tokens, i):
+				continue
+			case expressionKeywords[upper]:
+				convType = false
+				continue
+			}
+		}
+		if castType || convType {
+			continue
+		}
+		if i+1 < len(tokens) && tokens[i+1].kind == tokenSymbol && (tokens[i+1].text == "(" || tokens[i+1].text == ".") {
+			continue
+		}
+		name := tok.name()
+		if !seen[name] {
+			seen[name] = true
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// typeEnds are the words that end the type or test after IS.
+var typeEnds = map[string]bool{
+	"AND": true, "OR": true, "XOR": true, "WHEN": true, "THEN": true, "ELSE": true,
+	"END": true, "FROM": true,
+}
+
+// nextToON reports whether the token at i is next to ON, as ERROR and EMPTY
+// are in the NULL ON ERROR and ERROR ON EMPTY clauses of JSON functions.
+func nextToON(tokens []exprToken, i int) bool {
+	isON := func(j int) bool {
+		return j >= 0 && j < len(tokens) && tokens[j].kind == tokenIdent && strings.EqualFold(tokens[j].text, "ON")
+	}
+	return isON(i-1) || isON(i+1)
+}
+
+// ReplaceIdentifier returns expr, written for d, with every unquoted
+// identifier spelling name, in any letter case, replaced by replacement.
+// String literals and quoted identifiers are left as written.
+func ReplaceIdentifier(expr, name, replacement string, d Dialect) string {
+	var b strings.Builder
+	pos := 0
+	for _, tok := range tokenizeExpression(expr, d) {
+		// Tokens are substrings of expr in order, separated only by whitespace.
+		start := pos + strings.Index(expr[pos:], tok.text)
+		b.WriteString(expr[pos:start])
+		if tok.kind == tokenIdent && strings.EqualFold(tok.text, name) {
+			b.WriteString(replacement)
+		} else {
+			b.WriteString(tok.text)
+		}
+		pos = start + len(tok.text)
+	}
+	b.WriteString(expr[pos:])
+	return b.String()
+}
+
+// NormalizeExpression returns expr, written for d, with its whitespace and
+// keyword case made canonical, so two spellings of the same expression
+// compare equal. Identifiers and literals are kept as written.
+func NormalizeExpression(expr string, d Dialect) string {
+	var b strings.Builder
+	var prev exprToken
+	for i, tok := range tokenizeExpression(expr, d) {
+		text := tok.text
+		if tok.kind == tokenIdent && expressionKeywords[strings.ToUpper(text)] {
+			text = strings.ToUpper(text)
+		}
+		if i > 0 && spaceBetween(prev, tok) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(text)
+		prev = tok
+	}
+	return b.String()
+}
+
+// spaceBetween reports whether NormalizeExpression separates two tokens.
+func spaceBetween(prev, next exprToken) bool {
+	if prev.kind == tokenSymbol && (prev.text == "(" || prev.text == "[" || prev.text == "." || prev.text == "::") {
+		return false
+	}
+	if next.kind == tokenSymbol && (next.text == ")" || next.text == "]" || next.text == "," || next.text == "." || next.text == "::") {
+		return false
+	}
+	if next.kind == tokenSymbol && next.text == "[" && prev.kind != tokenSymbol {
+		return false
+	}
+	if next.kind == tokenSymbol && next.text == "(" && prev.kind != tokenSymbol {
+		return expressionKeywords[strings.ToUpper(prev.text)] && prev.kind == tokenIdent
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpressionColumns(t *testing.T) {
+	tests := []struct {
+		expr    string
+		dialect Dialect
+		want    []string
+	}{
+		{"age >= 0 AND age <= 200", DialectPostgreSQL, []string{"age"}},
+		{"email LIKE '%@%'", DialectPostgreSQL, []string{"email"}},
+		{"status IN ('draft', 'published') OR archived_at IS NOT NULL", DialectPostgreSQL, []string{"status", "archived_at"}},
+		{"'price > 0' = label", DialectPostgreSQL, []string{"label"}},
+		{"note <> 'it''s total'", DialectPostgreSQL, []string{"note"}},
+		{`note <> 'it\'s total' AND qty > 0`, DialectPostgreSQL, []string{"note", "qty"}},
+		{"name = N'café' OR name = _utf8mb4'x' OR code = X'1F'", DialectPostgreSQL, []string{"name", "code"}},
+		{"lower(email) = email", DialectPostgreSQL, []string{"email"}},
+		{"CAST(total AS DECIMAL(10, 2)) > limit_amount", DialectPostgreSQL, []string{"total", "limit_amount"}},
+		{"owner_id = current_setting('app.user_id')::int AND deleted = false", DialectPostgreSQL, []string{"owner_id", "deleted"}},
+		{"amount::double precision > 1.5e3", DialectPostgreSQL, []string{"amount"}},
+		{"t.price * `quantity` + [discount]", DialectMSSQL, []string{"price", "quantity", "discount"}},
+		{"[total] > 0 AND [line items] <> ''", DialectMSSQL, []string{"total", "line items"}},
+		{"status = ANY (ARRAY['a'::text, 'b'::text])", DialectPostgreSQL, []string{"status"}},
+		{"status = ANY (ARRAY['a'::text, 'b'::text])", DialectMSSQL, []string{"status"}},
+		{"tags[1] <> 'x' AND scores[idx] > 0", DialectPostgreSQL, []string{"tags", "scores", "idx"}},
+		{"[discount] > 0", DialectMySQL, []string{"discount"}},
+		{"CONVERT(name USING utf8mb4) <> ''", DialectMySQL, []string{"name"}},
+		{"extract(epoch FROM ends_at) > extract(EPOCH FROM starts_at)", DialectPostgreSQL, []string{"ends_at", "starts_at"}},
+		{"date_part('dow', created_at) < 6 AND EXTRACT(ISODOW FROM created_at) < 6", DialectPostgreSQL, []string{"created_at"}},
+		{"EXTRACT(YEAR_MONTH FROM created_at) > 202401", DialectMySQL, []string{"created_at"}},
+		{`"quoted" = 1`, DialectPostgreSQL, nil},
+		{"EXTRACT(YEAR FROM created_at) >= 2000", DialectPostgreSQL, []string{"created_at"}},
+		{"created_at < CURRENT_TIMESTAMP", DialectPostgreSQL, []string{"created_at"}},
+		{"CASE WHEN qty > 0 THEN price ELSE 0 END > 0", DialectPostgreSQL, []string{"qty", "price"}},
+		{"label = 'unterminated", DialectPostgreSQL, []string{"label"}},
+		{"code <> '' COLLATE utf8mb4_bin", DialectMySQL, []string{"code"}},
+		{`name COLLATE "C" < label COLLATE Latin1_General_BIN2`, DialectPostgreSQL, []string{"name", "label"}},
+		{"CONVERT(name, CHAR) <> '' AND CONVERT(price, DECIMAL(10, 2)) > 0", DialectMySQL, []string{"name", "price"}},
+		{"CONVERT(VARCHAR(10), code) <> '' AND TRY_CONVERT(INT, qty) > 0", DialectMSSQL, []string{"code", "qty"}},
+		{"JSON_VALUE(data, '$.a' RETURNING CHAR NULL ON ERROR) <> ''", DialectMySQL, []string{"data"}},
+		{"doc IS JSON AND meta IS NOT JSON OBJECT AND a IS NOT DISTINCT FROM b", DialectPostgreSQL, []string{"doc", "meta", "a", "b"}},
+		{"a IS b", DialectSQLite, []string{"a", "b"}},
+		{"(starts_at, ends_at) OVERLAPS (opens_at, closes_at)", DialectPostgreSQL, []string{"starts_at", "ends_at", "opens_at", "closes_at"}},
+		{"owner = CURRENT_ROLE", DialectPostgreSQL, []string{"owner"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExpressionColumns(tt.expr, tt.dialect))
+		})
+	}
+}
+
+func TestReplaceIdentifier(t *testing.T) {
+	tests := []struct {
+		expr    string
+		dialect Dialect
+		want    string
+	}{
+		{"VALUE >= 0", DialectPostgreSQL, "price >= 0"},
+		{"value  BETWEEN 0 AND  100", DialectPostgreSQL, "price  BETWEEN 0 AND  100"},
+		{"VALUE <> 'NO VALUE'", DialectPostgreSQL, "price <> 'NO VALUE'"},
+		{`"VALUE" > VALUES_MAX + VALUE`, DialectPostgreSQL, `"VALUE" > VALUES_MAX + price`},
+		{"[VALUE] > VALUE", DialectMSSQL, "[VALUE] > price"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ReplaceIdentifier(tt.expr, "VALUE", "price", tt.dialect), tt.expr)
+	}
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	assert.Equal(t, "age >= 0 AND age <= 200", NormalizeExpression("age>=0  and\n\tage <= 200", DialectPostgreSQL))
+	assert.Equal(t, NormalizeExpression("lower( email ) like '%@%'", DialectPostgreSQL), NormalizeExpression("lower(email) LIKE '%@%'", DialectPostgreSQL))
+	assert.Equal(t, "status IN ('a', 'b')", NormalizeExpression("status in ('a','b')", DialectPostgreSQL))
+	assert.Equal(t, "note = 'and  or'", NormalizeExpression("note   =   'and  or'", DialectPostgreSQL), "literals are kept as written")
+	assert.NotEqual(t, NormalizeExpression("Total > 0", DialectPostgreSQL), NormalizeExpression("total > 0", DialectPostgreSQL), "identifier case is kept")
+	assert.Equal(t, "tags[1] = ARRAY['a', 'b']", NormalizeExpression("tags [ 1 ]=array[ 'a','b' ]", DialectPostgreSQL))
+	assert.Equal(t, "[line items] > 0", NormalizeExpression("[line items]>0", DialectMSSQL))
+}
+
+func TestValidateExpressionReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		col     *Column
+		want    string
+	}{
+		{
+			name:    "check",
+			dialect: DialectPostgreSQL,
+			col:     &Column{Name: "discount", Type: DataTypeInt, Check: "discount <= totl"},
+			want:    `constraint "chk_orders_discount": check expression references nonexistent column "totl"`,
+		},
+		{
+			name:    "generation expression",
+			dialect: DialectPostgreSQL,
+			col:     &Column{Name: "cents", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "amount * 100"},
+			want:    `column "cents": generation expression references nonexistent column "amount"`,
+		},
+		{
+			name:    "self reference",
+			dialect: DialectPostgreSQL,
+			col:     &Column{Name: "cents", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "cents + total"},
+			want:    `generated column "cents" depends on itself: cents -> cents`,
+		},
+		{name: "string literal", dialect: DialectPostgreSQL, col: &Column{Name: "discount", Type: DataTypeInt, Check: "discount <= total AND 'totl' <> ''"}},
+		{name: "array", dialect: DialectPostgreSQL, col: &Column{Name: "status", Type: DataTypeString, Check: "status = ANY (ARRAY['a'::text, 'b'::text])"}},
+		{name: "extract field", dialect: DialectPostgreSQL, col: &Column{Name: "seconds", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "extract(epoch FROM total)"}},
+		{name: "convert using", dialect: DialectMySQL, col: &Column{Name: "name", Type: DataTypeString, Check: "CONVERT(name USING utf8mb4) <> ''"}},
+		{name: "collate", dialect: DialectMySQL, col: &Column{Name: "code", Type: DataTypeString, Check: "code <> '' COLLATE utf8mb4_bin"}},
+		{name: "convert type", dialect: DialectMySQL, col: &Column{Name: "name", Type: DataTypeString, Check: "CONVERT(name, CHAR) <> ''"}},
+		{name: "is json", dialect: DialectPostgreSQL, col: &Column{Name: "doc", Type: DataTypeJSON, Check: "doc IS JSON"}},
+		{name: "json returning", dialect: DialectMySQL, col: &Column{Name: "data", Type: DataTypeJSON, Check: "JSON_VALUE(data, '$.a' RETURNING CHAR) <> ''"}},
+		{name: "current role", dialect: DialectPostgreSQL, col: &Column{Name: "owner", Type: DataTypeString, Check: "owner = CURRENT_ROLE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(tt.dialect),
+				Tables: []*Table{{
+					Name:    "orders",
+					Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}, {Name: "total", Type: DataTypeInt}, tt.col},
+				}},
+			}
+			err := db.Validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestGeneratedColumnOrder(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{{
+			Name: "orders",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+				{Name: "total", Type: DataTypeInt},
+				{Name: "gross", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "net + tax"},
+				{Name: "net", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "total * 100"},
+				{Name: "tax", Type: DataTypeInt, IsGenerated: true, GenerationExpression: "net / 5"},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	order, err := db.Tables[0].GeneratedColumnOrder(DialectPostgreSQL)
+	require.NoError(t, err)
+	names := make([]string, len(order))
+	for i, c := range order {
+		names[i] = c.Name
+	}
+	assert.Equal(t, []string{"net", "tax", "gross"}, names)
+
+	db.Tables[0].Columns[3].GenerationExpression = "gross - tax"
+	_, err = db.Tables[0].GeneratedColumnOrder(DialectPostgreSQL)
+	require.Error(t, err)
+	assert.Equal(t, `generated column "gross" depends on itself: gross -> net -> gross`, err.Error())
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// extensionTypes maps raw type bases to the PostgreSQL extension that
+// provides them.
+var extensionTypes = map[string]string{
+	"CITEXT":    "citext",
+	"HSTORE":    "hstore",
+	"LTREE":     "ltree",
+	"GEOMETRY":  "postgis",
+	"GEOGRAPHY": "postgis",
+}
+
+// extensionFunctions maps functions commonly used in defaults, checks and
+// generated columns to the PostgreSQL extension that provides them.
+// gen_random_uuid() is built in since PostgreSQL 13 and is not listed.
+var extensionFunctions = map[string]string{
+	"uuid_generate_v1":   "uuid-ossp",
+	"uuid_generate_v1mc": "uuid-ossp",
+	"uuid_generate_v4":   "uuid-ossp",
+	"crypt":              "pgcrypto",
+	"gen_salt":           "pgcrypto",
+	"digest":             "pgcrypto",
+	"similarity":         "pg_trgm",
+}
+
+var functionCallRe = regexp.MustCompile(`(?i)\b([a-z_][a-z0-9_]*)\s*\(`)
+
+// FindExtension looks for a declared extension by name.
+func (db *Database) FindExtension(name string) *Extension {
+	for _, e := range db.Extensions {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// validateExtensions checks that every extension is named once.
+func (db *Database) validateExtensions() error {
+	seen := make(map[string]bool, len(db.Extensions))
+	for _, e := range db.Extensions {
+		if strings.TrimSpace(e.Name) == "" {
+			return errors.New("extension name is empty")
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("duplicate extension %q", e.Name)
+		}
+		seen[e.Name] = true
+	}
+	return nil
+}
+
+// extensionWarnings flags extensions declared for a schema that does not
+// target postgresql, and, when it does, columns whose type or expressions
+// depend on an extension that is not declared.
+func (db *Database) extensionWarnings(dialects []Dialect) []Warning {
+	var warnings []Warning
+	if !slices.Contains(dialects, DialectPostgreSQL) {
+		for i, e := range db.Extensions {
+			warnings = append(warnings, Warning{
+				Code:    WarningDialectUnsupported,
+				Object:  e.Name,
+				Path:    fmt.Sprintf("extensions[%d]", i),
+				Message: fmt.Sprintf("extension %q: extensions are only supported by postgresql, not %s", e.Name, dialectList(dialects)),
+			})
+		}
+		return warnings
+	}
+
+	for ti, t := range db.Tables {
+		for ci, c := range t.Columns {
+			for _, need := range c.requiredExtensions() {
+				if db.FindExtension(need.extension) != nil {
+					continue
+				}
+				warnings = append(warnings, Warning{
+					Code:    WarningMissingExtension,
+					Table:   t.Name,
+					Object:  c.Name,
+					Path:    fmt.Sprintf("tables[%d].columns[%d].%s", ti, ci, need.key),
+					Message: fmt.Sprintf("table %q, column %q: %s needs the %q extension; declare it under [[extensions]]", t.Name, c.Name, need.what, need.extension),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// extensionNeed is one extension a column depends on and why.
+type extensionNeed struct {
+	extension string
+	key       string // source key that introduced the dependency
+	what      string // human description, e.g. `type "citext"`
+}
+
+// requiredExtensions lists the extensions the column's raw type, default,
+// check and generation expressions depend on.
+func (c *Column) requiredExtensions() []extensionNeed {
+	var needs []extensionNeed
+	if ext, ok := extensionTypes[normalizeRawTypeBase(c.RawType)]; ok {
+		needs = append(needs, extensionNeed{extension: ext, key: "raw_type", what: fmt.Sprintf("type %q", c.RawType)})
+	}
+	exprs := []struct{ key, expr string }{{"check", c.Check}, {"generation_expression", c.GenerationExpression}}
+	if c.DefaultValue != nil {
+		exprs = append([]struct{ key, expr string }{{"default", *c.DefaultValue}}, exprs...)
+	}
+	for _, e := range exprs {
+		for _, m := range functionCallRe.FindAllStringSubmatch(e.expr, -1) {
+			fn := strings.ToLower(m[1])
+			ext, ok := extensionFunctions[fn]
+			if !ok || slices.ContainsFunc(needs, func(n extensionNeed) bool { return n.extension == ext && n.key == e.key }) {
+				continue
+			}
+			needs = append(needs, extensionNeed{extension: ext, key: e.key, what: fmt.Sprintf("%s()", fn)})
+		}
+	}
+	return needs
+}
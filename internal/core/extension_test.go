@@ -0,0 +1,89 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintMissingExtensions(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeUUID, RawType: "uuid", DefaultValue: new("uuid_generate_v4()")},
+				{Name: "email", Type: DataTypeString, RawType: "citext"},
+				{Name: "token", Type: DataTypeString, DefaultValue: new("gen_random_uuid()::text")},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, WarningMissingExtension, warnings[0].Code)
+	assert.Equal(t, "tables[0].columns[0].default", warnings[0].Path)
+	assert.Equal(t, `table "users", column "id": uuid_generate_v4() needs the "uuid-ossp" extension; declare it under [[extensions]]`, warnings[0].Message)
+	assert.Equal(t, "tables[0].columns[1].raw_type", warnings[1].Path)
+	assert.Equal(t, `table "users", column "email": type "citext" needs the "citext" extension; declare it under [[extensions]]`, warnings[1].Message)
+}
+
+func TestLintDeclaredExtensions(t *testing.T) {
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectPostgreSQL),
+		Extensions: []*Extension{{Name: "citext"}, {Name: "uuid-ossp", Schema: "extensions"}},
+		Tables: []*Table{{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeUUID, RawType: "uuid", DefaultValue: new("uuid_generate_v4()")},
+				{Name: "email", Type: DataTypeString, RawType: "citext"},
+				{Name: "token", Type: DataTypeString, DefaultValue: new("gen_random_uuid()::text")},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint())
+}
+
+func TestLintExtensionsOnOtherDialect(t *testing.T) {
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectMySQL),
+		Extensions: []*Extension{{Name: "citext"}},
+		Tables:     []*Table{{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt}}}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningDialectUnsupported, warnings[0].Code)
+	assert.Equal(t, "extensions[0]", warnings[0].Path)
+}
+
+func TestValidateExtensionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []*Extension
+		want       string
+	}{
+		{"duplicate", []*Extension{{Name: "citext"}, {Name: "citext"}}, `duplicate extension "citext"`},
+		{"empty name", []*Extension{{Name: " "}}, "extension name is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:       "app",
+				Dialect:    new(DialectPostgreSQL),
+				Extensions: tt.extensions,
+				Tables:     []*Table{{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt}}}},
+			}
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
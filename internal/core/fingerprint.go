@@ -0,0 +1,110 @@
+package core
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// FingerprintOptions controls what Fingerprint hashes.
+type FingerprintOptions struct {
+	// IncludeComments makes table, column and index comments part of the
+	// fingerprint. By default editing a comment does not change it.
+	IncludeComments bool
+}
+
+// fingerprintDoc is the canonical document that Fingerprint hashes. Its
+// encoding must stay stable across releases: adding fields changes every
+// fingerprint, so new model fields should be omitempty.
+type fingerprintDoc struct {
+	Name       string       `json:"name"`
+	Dialect    Dialect      `json:"dialect"`
+	Tables     []*Table     `json:"tables"`
+	Extensions []*Extension `json:"extensions,omitempty"`
+	EnumTypes  []*EnumType  `json:"enumTypes,omitempty"`
+	Domains    []*Domain    `json:"domains,omitempty"`
+}
+
+// Normalized returns a deep copy of db in canonical form: tables are sorted
+// by name, constraints within each table by type and name, and indexes,
+// policies, extensions, enum types and domains by name. Column order, column
+// lists of keys and indexes, and enum value order are kept because they are
+// part of the definition. Validation rules are not copied.
+func (db *Database) Normalized() (*Database, error) {
+	data, err := json.Marshal(db.Tables)
+	if err != nil {
+		return nil, fmt.Errorf("normalize: %w", err)
+	}
+	var tables []*Table
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return nil, fmt.Errorf("normalize: %w", err)
+	}
+
+	slices.SortStableFunc(tables, func(a, b *Table) int { return cmp.Compare(a.Name, b.Name) })
+	for _, t := range tables {
+		slices.SortStableFunc(t.Constraints, func(a, b *Constraint) int {
+			return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+		})
+		slices.SortStableFunc(t.Indexes, func(a, b *Index) int { return cmp.Compare(a.Name, b.Name) })
+		slices.SortStableFunc(t.Policies, func(a, b *Policy) int { return cmp.Compare(a.Name, b.Name) })
+	}
+
+	out := &Database{Name: db.Name, Tables: tables}
+	for _, e := range db.Extensions {
+		out.Extensions = append(out.Extensions, new(*e))
+	}
+	slices.SortStableFunc(out.Extensions, func(a, b *Extension) int { return cmp.Compare(a.Name, b.Name) })
+	for _, e := range db.EnumTypes {
+		out.EnumTypes = append(out.EnumTypes, &EnumType{Name: e.Name, Values: slices.Clone(e.Values), Comment: e.Comment})
+	}
+	slices.SortStableFunc(out.EnumTypes, func(a, b *EnumType) int { return cmp.Compare(a.Name, b.Name) })
+	for _, d := range db.Domains {
+		out.Domains = append(out.Domains, new(*d))
+	}
+	slices.SortStableFunc(out.Domains, func(a, b *Domain) int { return cmp.Compare(a.Name, b.Name) })
+	if db.Dialect != nil {
+		out.Dialect = new(*db.Dialect)
+	}
+	return out, nil
+}
+
+// Fingerprint returns a stable content hash of the schema formatted as
+// "sha256:<hex>". It is independent of table, constraint and index order, so
+// it only changes when the schema itself does.
+func (db *Database) Fingerprint(opts FingerprintOptions) (string, error) {
+	n, err := db.Normalized()
+	if err != nil {
+		return "", err
+	}
+	if !opts.IncludeComments {
+		for _, e := range n.EnumTypes {
+			e.Comment = ""
+		}
+		for _, d := range n.Domains {
+			d.Comment = ""
+		}
+		for _, t := range n.Tables {
+			t.Comment = ""
+			for _, c := range t.Columns {
+				c.Comment = ""
+			}
+			for _, idx := range t.Indexes {
+				idx.Comment = ""
+			}
+		}
+	}
+
+	doc := fingerprintDoc{Name: n.Name, Tables: n.Tables, Extensions: n.Extensions, EnumTypes: n.EnumTypes, Domains: n.Domains}
+	if n.Dialect != nil {
+		doc.Dialect = *n.Dialect
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fingerprintDatabase() *Database {
+	return &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{
+			{
+				Name:    "users",
+				Comment: "accounts",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "email", Type: DataTypeString, Comment: "login"},
+				},
+				Constraints: []*Constraint{
+					{Name: "uq_email", Type: ConstraintUnique, Columns: []string{"email"}},
+					{Name: "pk_users", Type: ConstraintPrimaryKey, Columns: []string{"id"}},
+				},
+				Indexes: []*Index{
+					{Name: "idx_b", Columns: []ColumnIndex{{Name: "email"}}},
+					{Name: "idx_a", Columns: []ColumnIndex{{Name: "id"}}},
+				},
+			},
+			{Name: "roles", Columns: []*Column{{Name: "id", Type: DataTypeInt}}},
+		},
+	}
+}
+
+func TestFingerprintFormatAndStability(t *testing.T) {
+	fp, err := fingerprintDatabase().Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(fp, "sha256:"))
+	assert.Len(t, fp, len("sha256:")+64)
+
+	again, err := fingerprintDatabase().Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, fp, again)
+}
+
+func TestFingerprintIgnoresOrder(t *testing.T) {
+	a := fingerprintDatabase()
+	b := fingerprintDatabase()
+	slices.Reverse(b.Tables)
+	slices.Reverse(b.Tables[1].Constraints)
+	slices.Reverse(b.Tables[1].Indexes)
+
+	fa, err := a.Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+	fb, err := b.Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, fa, fb)
+
+	// The input is not modified.
+	assert.Equal(t, "roles", b.Tables[0].Name)
+}
+
+func TestFingerprintDetectsChanges(t *testing.T) {
+	base, err := fingerprintDatabase().Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+
+	reordered := fingerprintDatabase()
+	slices.Reverse(reordered.Tables[0].Columns)
+	changed := fingerprintDatabase()
+	changed.Tables[0].Columns[1].Nullable = true
+
+	for name, db := range map[string]*Database{"column order": reordered, "column nullability": changed} {
+		fp, err := db.Fingerprint(FingerprintOptions{})
+		require.NoError(t, err)
+		assert.NotEqual(t, base, fp, name)
+	}
+}
+
+func TestFingerprintComments(t *testing.T) {
+	a := fingerprintDatabase()
+	b := fingerprintDatabase()
+	b.Tables[0].Comment = "edited"
+	b.Tables[0].Columns[1].Comment = ""
+
+	fa, err := a.Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+	fb, err := b.Fingerprint(FingerprintOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, fa, fb)
+
+	fa, err = a.Fingerprint(FingerprintOptions{IncludeComments: true})
+	require.NoError(t, err)
+	fb, err = b.Fingerprint(FingerprintOptions{IncludeComments: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, fa, fb)
+}
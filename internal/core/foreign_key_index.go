@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// fkAutoIndexDialects create an index on the referencing columns of a
+// foreign key themselves when no existing index covers them. The other
+// dialects leave the columns unindexed, which makes joins and cascading
+// deletes from the parent scan the child table.
+var fkAutoIndexDialects = []Dialect{DialectMySQL, DialectMariaDB, DialectTiDB}
+
+// coversColumns reports whether keyColumns starts with columns, in any
+// order, so the key can serve lookups on them.
+func coversColumns(keyColumns, columns []string) bool {
+	if len(keyColumns) < len(columns) {
+		return false
+	}
+	for _, c := range keyColumns[:len(columns)] {
+		if !slices.Contains(columns, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasIndexOn reports whether a primary key, unique constraint or index of
+// the table that exists for d has columns as its leading columns.
+func (t *Table) hasIndexOn(columns []string, d Dialect) bool {
+	for _, con := range t.Constraints {
+		if (con.Type == ConstraintPrimaryKey || con.Type == ConstraintUnique) && coversColumns(con.Columns, columns) {
+			return true
+		}
+	}
+	for _, idx := range t.Indexes {
+		if !idx.AppliesTo(d) {
+			continue
+		}
+		names := make([]string, len(idx.Columns))
+		for i, c := range idx.Columns {
+			names[i] = c.Name
+		}
+		if coversColumns(names, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForeignKeyIndexes returns an index named idx_<table>_<columns> for every
+// foreign key of the table whose columns no key or index covers for d.
+// Dialects that index foreign key columns on their own get none. The table
+// is not modified.
+func (t *Table) ForeignKeyIndexes(d Dialect) []*Index {
+	if slices.Contains(fkAutoIndexDialects, d) {
+		return nil
+	}
+	var indexes []*Index
+	for _, con := range t.Constraints {
+		if con.Type != ConstraintForeignKey || t.hasIndexOn(con.Columns, d) {
+			continue
+		}
+		idx := &Index{Name: fmt.Sprintf("idx_%s_%s", strings.ToLower(t.Name), strings.ToLower(strings.Join(con.Columns, "_")))}
+		for _, c := range con.Columns {
+			idx.Columns = append(idx.Columns, ColumnIndex{Name: c})
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes
+}
+
+// unindexedForeignKeys flags foreign keys whose columns no key or index
+// covers for a target dialect that does not index them automatically.
+func (t *Table) unindexedForeignKeys(db *Database, idx int, dialects []Dialect) []Warning {
+	var warnings []Warning
+	for i, con := range t.Constraints {
+		if con.Type != ConstraintForeignKey {
+			continue
+		}
+		ref := db.FindTable(con.ReferencedTable)
+		var missing []Dialect
+		for _, d := range dialects {
+			if slices.Contains(fkAutoIndexDialects, d) || !t.AppliesTo(d) || ref != nil && !ref.AppliesTo(d) {
+				continue
+			}
+			if !t.hasIndexOn(con.Columns, d) {
+				missing = append(missing, d)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarningUnindexedForeignKey,
+			Table:   t.Name,
+			Object:  con.Name,
+			Path:    fmt.Sprintf("tables[%d].constraints[%d]", idx, i),
+			Message: fmt.Sprintf("table %q, constraint %q: no index covers the foreign key columns (%s) for %s; add one to avoid table scans on joins and cascades", t.Name, con.Name, strings.Join(con.Columns, ", "), dialectList(missing)),
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKeyIndexes(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{
+			{
+				Name: "users",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "tenant_id", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "uq_users_tenant_id_id", Type: ConstraintUnique, Columns: []string{"tenant_id", "id"}},
+				},
+			},
+			{
+				Name: "orders",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "user_id", Type: DataTypeInt},
+					{Name: "tenant_id", Type: DataTypeInt},
+					{Name: "created_by", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "fk_orders_users", Type: ConstraintForeignKey, Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+					{Name: "fk_orders_tenant_users", Type: ConstraintForeignKey, Columns: []string{"user_id", "tenant_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id", "tenant_id"}},
+					{Name: "fk_orders_creator", Type: ConstraintForeignKey, Columns: []string{"created_by"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				},
+				Indexes: []*Index{
+					{Name: "idx_orders_tenant_user", Columns: []ColumnIndex{{Name: "tenant_id"}, {Name: "user_id"}, {Name: "id"}}},
+					{Name: "idx_orders_created_by", Columns: []ColumnIndex{{Name: "created_by"}}, Dialects: []Dialect{DialectMySQL}},
+				},
+			},
+		},
+	}
+	require.NoError(t, db.Validate())
+	orders := db.FindTable("orders")
+
+	// user_id is not the leading column of idx_orders_tenant_user, and the
+	// index on created_by is limited to mysql.
+	assert.Equal(t, []*Index{
+		{Name: "idx_orders_user_id", Columns: []ColumnIndex{{Name: "user_id"}}},
+		{Name: "idx_orders_created_by", Columns: []ColumnIndex{{Name: "created_by"}}},
+	}, orders.ForeignKeyIndexes(DialectPostgreSQL))
+	assert.Empty(t, orders.ForeignKeyIndexes(DialectMySQL))
+
+	orders.Indexes = nil
+	names := []string{}
+	for _, idx := range orders.ForeignKeyIndexes(DialectSQLite) {
+		names = append(names, idx.Name)
+	}
+	assert.Equal(t, []string{"idx_orders_user_id", "idx_orders_user_id_tenant_id", "idx_orders_created_by"}, names)
+}
+
+func TestLintUnindexedForeignKey(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{
+			{
+				Name: "users",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "tenant_id", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "uq_users_tenant_id_id", Type: ConstraintUnique, Columns: []string{"tenant_id", "id"}},
+				},
+			},
+			{
+				Name: "orders",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "user_id", Type: DataTypeInt},
+					{Name: "tenant_id", Type: DataTypeInt},
+					{Name: "created_by", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "fk_orders_users", Type: ConstraintForeignKey, Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+					{Name: "fk_orders_tenant_users", Type: ConstraintForeignKey, Columns: []string{"user_id", "tenant_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id", "tenant_id"}},
+					{Name: "fk_orders_creator", Type: ConstraintForeignKey, Columns: []string{"created_by"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				},
+				Indexes: []*Index{
+					{Name: "idx_orders_tenant_user", Columns: []ColumnIndex{{Name: "tenant_id"}, {Name: "user_id"}, {Name: "id"}}},
+					{Name: "idx_orders_created_by", Columns: []ColumnIndex{{Name: "created_by"}}, Dialects: []Dialect{DialectMySQL}},
+				},
+			},
+		},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint(), "mysql indexes foreign keys itself")
+
+	warnings := db.Lint(DialectPostgreSQL, DialectSQLite)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, WarningUnindexedForeignKey, warnings[0].Code)
+	assert.Equal(t, "tables[1].constraints[0]", warnings[0].Path)
+	assert.Equal(t, "tables[1].constraints[2]", warnings[1].Path)
+	assert.Equal(t, `table "orders", constraint "fk_orders_creator": no index covers the foreign key columns (created_by) for postgresql, sqlite; add one to avoid table scans on joins and cascades`, warnings[1].Message)
+}
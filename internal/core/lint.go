@@ -0,0 +1,182 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// uniqueExpressionDialects support UNIQUE constraints over expressions, as
+// unique expression (function-based) indexes.
+var uniqueExpressionDialects = []Dialect{
+	DialectPostgreSQL, DialectMySQL, DialectTiDB, DialectSQLite, DialectOracle, DialectDB2,
+}
+
+// Lint runs the non-fatal schema checks and returns every finding in table
+// order. Lint expects a database that has already passed Validate.
+//
+// targets lists additional dialects the schema is generated for (e.g. from
+// --to); options and settings meant for those dialects are not flagged.
+func (db *Database) Lint(targets ...Dialect) []Warning {
+	if db == nil || db.Dialect == nil {
+		return nil
+	}
+	dialects := append([]Dialect{*db.Dialect}, targets...)
+
+	warnings := db.extensionWarnings(dialects)
+	warnings = append(warnings, db.domainWarnings(dialects)...)
+	warnings = append(warnings, db.excludedReferences(dialects)...)
+	warnings = append(warnings, db.namingWarnings()...)
+	warnings = append(warnings, db.missingPrimaryKeys()...)
+	for i, table := range db.Tables {
+		warnings = append(warnings, table.strayDialectOptions(i, dialects)...)
+		warnings = append(warnings, table.unsupportedConstraintFeatures(i, dialects)...)
+		warnings = append(warnings, table.unsupportedRowLevelSecurity(i, dialects)...)
+		warnings = append(warnings, table.unsupportedSetColumns(i, dialects)...)
+		warnings = append(warnings, table.looseStrictColumns(i, *db.Dialect)...)
+		warnings = append(warnings, table.rowSizeWarnings(i, dialects, *db.Dialect)...)
+		warnings = append(warnings, table.implicitCollations(i, *db.Dialect)...)
+		warnings = append(warnings, table.unindexedForeignKeys(db, i, dialects)...)
+	}
+	return warnings
+}
+
+// unsupportedConstraintFeatures flags EXCLUDE constraints and UNIQUE
+// constraints using expressions or NULLS NOT DISTINCT when no target dialect
+// supports them.
+func (t *Table) unsupportedConstraintFeatures(idx int, dialects []Dialect) []Warning {
+	supports := func(set []Dialect) bool {
+		return slices.ContainsFunc(dialects, func(d Dialect) bool { return slices.Contains(set, d) })
+	}
+	var warnings []Warning
+	for i, con := range t.Constraints {
+		path := fmt.Sprintf("tables[%d].constraints[%d]", idx, i)
+		if con.Type == ConstraintExclusion && !supports([]Dialect{DialectPostgreSQL}) {
+			warnings = append(warnings, Warning{
+				Code:    WarningDialectUnsupported,
+				Table:   t.Name,
+				Object:  con.Name,
+				Path:    path,
+				Message: fmt.Sprintf("table %q, constraint %q: EXCLUDE constraints are only supported by postgresql, not %s", t.Name, con.Name, dialectList(dialects)),
+			})
+		}
+		if con.Type != ConstraintUnique {
+			continue
+		}
+		if len(con.Expressions) > 0 && !supports(uniqueExpressionDialects) {
+			warnings = append(warnings, Warning{
+				Code:    WarningDialectUnsupported,
+				Table:   t.Name,
+				Object:  con.Name,
+				Path:    path + ".expressions",
+				Message: fmt.Sprintf("table %q, constraint %q: unique expressions are not supported by %s", t.Name, con.Name, dialectList(dialects)),
+			})
+		}
+		if con.NullsNotDistinct && !supports([]Dialect{DialectPostgreSQL}) {
+			warnings = append(warnings, Warning{
+				Code:    WarningDialectUnsupported,
+				Table:   t.Name,
+				Object:  con.Name,
+				Path:    path + ".nulls_not_distinct",
+				Message: fmt.Sprintf("table %q, constraint %q: NULLS NOT DISTINCT is only supported by postgresql; %s treats NULLs as distinct", t.Name, con.Name, dialectList(dialects)),
+			})
+		}
+	}
+	return warnings
+}
+
+// unsupportedRowLevelSecurity flags row-level security and policies when
+// postgresql is not among the target dialects.
+func (t *Table) unsupportedRowLevelSecurity(idx int, dialects []Dialect) []Warning {
+	if slices.Contains(dialects, DialectPostgreSQL) {
+		return nil
+	}
+	var warnings []Warning
+	if t.RowLevelSecurity {
+		warnings = append(warnings, Warning{
+			Code:    WarningDialectUnsupported,
+			Table:   t.Name,
+			Path:    fmt.Sprintf("tables[%d].row_level_security", idx),
+			Message: fmt.Sprintf("table %q: row-level security is only supported by postgresql, not %s", t.Name, dialectList(dialects)),
+		})
+	}
+	for i, p := range t.Policies {
+		warnings = append(warnings, Warning{
+			Code:    WarningDialectUnsupported,
+			Table:   t.Name,
+			Object:  p.Name,
+			Path:    fmt.Sprintf("tables[%d].policies[%d]", idx, i),
+			Message: fmt.Sprintf("table %q, policy %q: policies are only supported by postgresql, not %s", t.Name, p.Name, dialectList(dialects)),
+		})
+	}
+	return warnings
+}
+
+// domainWarnings flags domains whose NOT NULL, default or check would be
+// lost when no target dialect has domains and columns fall back to the base
+// type.
+func (db *Database) domainWarnings(dialects []Dialect) []Warning {
+	if slices.Contains(dialects, DialectPostgreSQL) {
+		return nil
+	}
+	var warnings []Warning
+	for i, d := range db.Domains {
+		if !d.NotNull && d.Default == nil && d.Check == "" {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarningDialectUnsupported,
+			Object:  d.Name,
+			Path:    fmt.Sprintf("domains[%d]", i),
+			Message: fmt.Sprintf("domain %q: domains are only supported by postgresql; %s columns use the base type %q without its constraints", d.Name, dialectList(dialects), d.BaseType),
+		})
+	}
+	return warnings
+}
+
+// unsupportedSetColumns flags SET columns when no MySQL-family dialect is
+// targeted; other dialects have to store them as text.
+func (t *Table) unsupportedSetColumns(idx int, dialects []Dialect) []Warning {
+	if slices.ContainsFunc(dialects, func(d Dialect) bool { return slices.Contains(mysqlFamily, d) }) {
+		return nil
+	}
+	var warnings []Warning
+	for i, c := range t.Columns {
+		if c.Type != DataTypeSet {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarningDialectUnsupported,
+			Table:   t.Name,
+			Object:  c.Name,
+			Path:    fmt.Sprintf("tables[%d].columns[%d].type", idx, i),
+			Message: fmt.Sprintf("table %q, column %q: SET columns are only supported by mysql, mariadb and tidb; %s stores them as text", t.Name, c.Name, dialectList(dialects)),
+		})
+	}
+	return warnings
+}
+
+// sqliteStrictTypes are the only column types a SQLite STRICT table accepts.
+var sqliteStrictTypes = []string{"INT", "INTEGER", "REAL", "TEXT", "BLOB", "ANY"}
+
+// looseStrictColumns flags raw types a SQLite STRICT table rejects. Raw types
+// only apply to the declared dialect, so only sqlite schemas are checked.
+func (t *Table) looseStrictColumns(idx int, dialect Dialect) []Warning {
+	if dialect != DialectSQLite || t.Options.SQLite == nil || !t.Options.SQLite.Strict {
+		return nil
+	}
+	var warnings []Warning
+	for i, c := range t.Columns {
+		if c.RawType == "" || slices.Contains(sqliteStrictTypes, normalizeRawTypeBase(c.RawType)) {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarningDialectUnsupported,
+			Table:   t.Name,
+			Object:  c.Name,
+			Path:    fmt.Sprintf("tables[%d].columns[%d].raw_type", idx, i),
+			Message: fmt.Sprintf("table %q, column %q: raw type %q is not allowed in a STRICT table; use one of %s", t.Name, c.Name, c.RawType, strings.Join(sqliteStrictTypes, ", ")),
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// NamingRules holds the patterns constraint and index names must match,
+// e.g. "^fk_[a-z0-9_]+$" for foreign keys. An empty pattern accepts any name.
+type NamingRules struct {
+	PrimaryKey string `json:"primaryKey,omitempty"`
+	Unique     string `json:"unique,omitempty"`
+	ForeignKey string `json:"foreignKey,omitempty"`
+	Check      string `json:"check,omitempty"`
+	Exclusion  string `json:"exclusion,omitempty"`
+	Index      string `json:"index,omitempty"`
+}
+
+// namingRule is one entry of NamingRules.
+type namingRule struct {
+	key     string // [validation.naming] key, for messages
+	noun    string
+	pattern string
+}
+
+// constraintRule returns the rule for constraints of type ct.
+func (r *NamingRules) constraintRule(ct ConstraintType) namingRule {
+	switch ct {
+	case ConstraintPrimaryKey:
+		return namingRule{"primary_key", "primary key", r.PrimaryKey}
+	case ConstraintUnique:
+		return namingRule{"unique", "unique constraint", r.Unique}
+	case ConstraintForeignKey:
+		return namingRule{"foreign_key", "foreign key", r.ForeignKey}
+	case ConstraintCheck:
+		return namingRule{"check", "check constraint", r.Check}
+	case ConstraintExclusion:
+		return namingRule{"exclusion", "exclusion constraint", r.Exclusion}
+	}
+	return namingRule{}
+}
+
+func (r *NamingRules) indexRule() namingRule {
+	return namingRule{"index", "index", r.Index}
+}
+
+// compile compiles the non-empty patterns, keyed by pattern.
+func (r *NamingRules) compile() (map[string]*regexp.Regexp, error) {
+	compiled := make(map[string]*regexp.Regexp)
+	rules := []namingRule{r.indexRule()}
+	for _, ct := range []ConstraintType{ConstraintPrimaryKey, ConstraintUnique, ConstraintForeignKey, ConstraintCheck, ConstraintExclusion} {
+		rules = append(rules, r.constraintRule(ct))
+	}
+	for _, rule := range rules {
+		if rule.pattern == "" || compiled[rule.pattern] != nil {
+			continue
+		}
+		re, err := regexp.Compile(rule.pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naming.%s pattern %q: %w", rule.key, rule.pattern, err)
+		}
+		compiled[rule.pattern] = re
+	}
+	return compiled, nil
+}
+
+// validateNamingRules checks that the [validation.naming] patterns compile.
+func (db *Database) validateNamingRules() error {
+	if db.Validation == nil || db.Validation.Naming == nil {
+		return nil
+	}
+	_, err := db.Validation.Naming.compile()
+	return err
+}
+
+// namingWarnings flags constraints and indexes whose names do not match the
+// pattern for their kind in [validation.naming].
+func (db *Database) namingWarnings() []Warning {
+	if db.Validation == nil || db.Validation.Naming == nil {
+		return nil
+	}
+	rules := db.Validation.Naming
+	compiled, err := rules.compile()
+	if err != nil {
+		return nil
+	}
+	var warnings []Warning
+	check := func(t *Table, name, path string, rule namingRule) {
+		re := compiled[rule.pattern]
+		if re == nil || re.MatchString(name) {
+			return
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarningNamingConvention,
+			Table:   t.Name,
+			Object:  name,
+			Path:    path,
+			Message: fmt.Sprintf("table %q: %s name %q does not match naming.%s pattern %q", t.Name, rule.noun, name, rule.key, rule.pattern),
+		})
+	}
+	for i, t := range db.Tables {
+		for j, con := range t.Constraints {
+			check(t, con.Name, fmt.Sprintf("tables[%d].constraints[%d]", i, j), rules.constraintRule(con.Type))
+		}
+		for j, idx := range t.Indexes {
+			check(t, idx.Name, fmt.Sprintf("tables[%d].indexes[%d]", i, j), rules.indexRule())
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintNamingConvention(t *testing.T) {
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectMySQL),
+		Validation: &ValidationRules{Naming: &NamingRules{PrimaryKey: "^pk_", Unique: "^uq_", ForeignKey: "^fk_", Index: "^idx_"}},
+		Tables: []*Table{
+			{
+				Name:    "users",
+				Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}, {Name: "email", Type: DataTypeString, Unique: true}},
+			},
+			{
+				Name: "orders",
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "user_id", Type: DataTypeInt},
+				},
+				Constraints: []*Constraint{
+					{Name: "orders_user_fk", Type: ConstraintForeignKey, Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				},
+				Indexes: []*Index{
+					{Name: "idx_orders_user_id", Columns: []ColumnIndex{{Name: "user_id"}}},
+					{Name: "orders_created", Columns: []ColumnIndex{{Name: "id"}}},
+				},
+			},
+		},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, WarningNamingConvention, warnings[0].Code)
+	assert.Equal(t, "orders_user_fk", warnings[0].Object)
+	assert.Equal(t, "tables[1].constraints[0]", warnings[0].Path)
+	assert.Equal(t, `table "orders": foreign key name "orders_user_fk" does not match naming.foreign_key pattern "^fk_"`, warnings[0].Message)
+	assert.Equal(t, "tables[1].indexes[1]", warnings[1].Path)
+	assert.Equal(t, `table "orders": index name "orders_created" does not match naming.index pattern "^idx_"`, warnings[1].Message)
+
+	db.Validation.Naming = &NamingRules{}
+	assert.Empty(t, db.Lint(), "empty patterns accept any name")
+}
+
+func TestValidateNamingRules(t *testing.T) {
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectMySQL),
+		Validation: &ValidationRules{Naming: &NamingRules{Check: "chk_("}},
+		Tables:     []*Table{{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}}}},
+	}
+	err := db.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid naming.check pattern "chk_("`)
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// hasPrimaryKey reports whether the table declares a primary key, as a
+// constraint or on its columns.
+func (t *Table) hasPrimaryKey() bool {
+	return t.PrimaryKey() != nil || slices.ContainsFunc(t.Columns, func(c *Column) bool { return c.PrimaryKey })
+}
+
+// uniqueKeys returns the column lists of the unique constraints and unique
+// indexes of the table, skipping unique expressions.
+func (t *Table) uniqueKeys() [][]string {
+	var keys [][]string
+	for _, con := range t.Constraints {
+		if con.Type == ConstraintUnique && len(con.Expressions) == 0 {
+			keys = append(keys, con.Columns)
+		}
+	}
+	for _, idx := range t.Indexes {
+		if idx.Unique {
+			keys = append(keys, idx.Names())
+		}
+	}
+	return keys
+}
+
+// notNull reports whether every column is a NOT NULL column of the table.
+func (t *Table) notNull(columns []string) bool {
+	for _, name := range columns {
+		if c := t.FindColumn(name); c == nil || c.Nullable {
+			return false
+		}
+	}
+	return true
+}
+
+// missingPrimaryKeys flags tables without a primary key when [validation]
+// require_primary_key is set. Tables marked allow_no_primary_key are skipped.
+// A unique key does not take its place: tools and replicas that need a
+// primary key do not accept one, and a key with nullable columns cannot even
+// tell rows apart.
+func (db *Database) missingPrimaryKeys() []Warning {
+	if db.Validation == nil || !db.Validation.RequirePrimaryKey {
+		return nil
+	}
+	var warnings []Warning
+	for i, t := range db.Tables {
+		if t.AllowNoPrimaryKey || t.hasPrimaryKey() {
+			continue
+		}
+		msg := fmt.Sprintf("table %q: has no primary key; add one, or set allow_no_primary_key = true on the table", t.Name)
+		keys := t.uniqueKeys()
+		if len(keys) > 0 && !slices.ContainsFunc(keys, t.notNull) {
+			msg = fmt.Sprintf("table %q: has no primary key and its unique key (%s) allows NULLs, so it cannot identify rows for replication; add a primary key, or set allow_no_primary_key = true on the table",
+				t.Name, strings.Join(keys[0], ", "))
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarningMissingPrimaryKey,
+			Table:   t.Name,
+			Path:    fmt.Sprintf("tables[%d]", i),
+			Message: msg,
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintMissingPrimaryKey(t *testing.T) {
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectMySQL),
+		Validation: &ValidationRules{RequirePrimaryKey: true},
+		Tables: []*Table{
+			{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}}},
+			{
+				Name:        "memberships",
+				Columns:     []*Column{{Name: "user_id", Type: DataTypeInt}, {Name: "group_id", Type: DataTypeInt}},
+				Constraints: []*Constraint{{Name: "pk_memberships", Type: ConstraintPrimaryKey, Columns: []string{"user_id", "group_id"}}},
+			},
+			{Name: "events", Columns: []*Column{{Name: "payload", Type: DataTypeJSON}}},
+		},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningMissingPrimaryKey, warnings[0].Code)
+	assert.Equal(t, "events", warnings[0].Table)
+	assert.Equal(t, "tables[2]", warnings[0].Path)
+	assert.Contains(t, warnings[0].Message, `table "events": has no primary key`)
+}
+
+func TestLintMissingPrimaryKeyUniqueKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *Table
+		want  string
+	}{
+		{
+			name: "not null unique constraint",
+			table: &Table{
+				Name:    "accounts",
+				Columns: []*Column{{Name: "email", Type: DataTypeString, Unique: true}},
+			},
+			want: `table "accounts": has no primary key; add one, or set allow_no_primary_key = true on the table`,
+		},
+		{
+			name: "not null unique index",
+			table: &Table{
+				Name:    "accounts",
+				Columns: []*Column{{Name: "email", Type: DataTypeString}},
+				Indexes: []*Index{{Name: "uq_accounts_email", Unique: true, Columns: []ColumnIndex{{Name: "email"}}}},
+			},
+			want: `table "accounts": has no primary key; add one`,
+		},
+		{
+			name: "nullable unique index",
+			table: &Table{
+				Name:    "accounts",
+				Columns: []*Column{{Name: "email", Type: DataTypeString, Nullable: true}},
+				Indexes: []*Index{{Name: "uq_accounts_email", Unique: true, Columns: []ColumnIndex{{Name: "email"}}}},
+			},
+			want: `table "accounts": has no primary key and its unique key (email) allows NULLs`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:       "app",
+				Dialect:    new(DialectMySQL),
+				Validation: &ValidationRules{RequirePrimaryKey: true},
+				Tables:     []*Table{tt.table},
+			}
+			require.NoError(t, db.Validate())
+
+			warnings := db.Lint()
+			require.Len(t, warnings, 1)
+			assert.Equal(t, WarningMissingPrimaryKey, warnings[0].Code)
+			assert.Contains(t, warnings[0].Message, tt.want)
+		})
+	}
+}
+
+func TestLintMissingPrimaryKeyOptOut(t *testing.T) {
+	events := &Table{Name: "events", Columns: []*Column{{Name: "payload", Type: DataTypeJSON}}}
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectMySQL),
+		Validation: &ValidationRules{},
+		Tables:     []*Table{events},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint(), "rule is off by default")
+
+	db.Validation.RequirePrimaryKey = true
+	events.AllowNoPrimaryKey = true
+	assert.Empty(t, db.Lint())
+}
@@ -144,7 +144,7 @@ var postgresqlTypes = toSet(
 	"PG_LSN", "PG_SNAPSHOT", "TXID_SNAPSHOT",
 
 	// Other
-	"HSTORE", "LTREE",
+	"HSTORE", "LTREE", "CITEXT",
 	"GEOGRAPHY", "GEOMETRY",
 	"RECORD", "CSTRING",
 )
@@ -0,0 +1,286 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MySQLMaxRowSize is the most bytes the columns of a MySQL row may
+	// declare, whatever the storage engine. TEXT and BLOB columns only count
+	// with the size of their pointer.
+	MySQLMaxRowSize = 65535
+	// InnoDBMaxInlineRowSize is the most bytes an InnoDB row may store on its
+	// page with the default 16KB page size.
+	InnoDBMaxInlineRowSize = 8126
+)
+
+// defaultStringLength is the VARCHAR length assumed for string columns with
+// no raw type to read a length from.
+const defaultStringLength = 255
+
+// RowSizeColumn is the estimated size of a single column.
+type RowSizeColumn struct {
+	// Name is the column name.
+	Name string
+	// Bytes counts toward the MySQL row size limit.
+	Bytes int
+	// InlineBytes is stored on the InnoDB page for the row format.
+	InlineBytes int
+}
+
+// RowSize is the estimated size of a MySQL row.
+type RowSize struct {
+	// Bytes is the estimate checked against MySQLMaxRowSize, including the
+	// NULL bitmap.
+	Bytes int
+	// InlineBytes is the estimate checked against InnoDBMaxInlineRowSize.
+	InlineBytes int
+	// RowFormat is the InnoDB row format the inline estimate is for.
+	RowFormat string
+	// Columns holds every column in table order.
+	Columns []RowSizeColumn
+}
+
+// largestColumns formats up to n of the columns largest by the given size
+// for messages, e.g. `body (64002 bytes)`.
+func largestColumns(columns []RowSizeColumn, n int, bytes func(RowSizeColumn) int) string {
+	columns = slices.Clone(columns)
+	slices.SortStableFunc(columns, func(a, b RowSizeColumn) int { return cmp.Compare(bytes(b), bytes(a)) })
+	parts := make([]string, 0, n)
+	for _, c := range columns[:min(n, len(columns))] {
+		parts = append(parts, fmt.Sprintf("%s (%d bytes)", c.Name, bytes(c)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// charsetWidths are the bytes per character of MySQL character sets.
+var charsetWidths = map[string]int{
+	"utf8mb4": 4, "utf8mb3": 3, "utf8": 3, "utf16": 4, "utf16le": 4, "utf32": 4,
+	"ucs2": 2, "latin1": 1, "latin2": 1, "ascii": 1, "binary": 1,
+	"gbk": 2, "gb2312": 2, "gb18030": 4, "big5": 2, "sjis": 2, "ujis": 3, "euckr": 2,
+}
+
+var typeArgsRe = regexp.MustCompile(`\(([^)]*)\)`)
+
+// MySQLRowSize estimates the size of a row of the table in MySQL. When
+// useRawTypes is false the raw types are meant for another dialect and only
+// the portable types are read, with string columns assumed to be
+// VARCHAR(255).
+//
+// Variable-length columns are counted at their maximum length. For the
+// inline estimate, DYNAMIC and COMPRESSED rows (the InnoDB default) store
+// values longer than 40 bytes off-page behind a 20-byte pointer, while
+// COMPACT and REDUNDANT rows keep a 768-byte prefix on the page.
+func (t *Table) MySQLRowSize(useRawTypes bool) RowSize {
+	size := RowSize{RowFormat: "DYNAMIC"}
+	if o := t.Options.MySQL; o != nil {
+		if o.RowFormat != "" {
+			size.RowFormat = strings.ToUpper(o.RowFormat)
+		}
+	}
+	prefixInline := size.RowFormat == "COMPACT" || size.RowFormat == "REDUNDANT"
+
+	nullable := 0
+	for _, c := range t.Columns {
+		if c.Nullable {
+			nullable++
+		}
+		width, ok := charsetWidths[t.mysqlCharset(c)]
+		if !ok {
+			width = 4
+		}
+		raw := ""
+		if useRawTypes {
+			raw = c.RawType
+		}
+		bytes, variable, offPage := mysqlColumnSize(c, raw, width)
+		inline := bytes
+		switch {
+		case offPage:
+			inline = 20
+			if prefixInline {
+				inline = 768 + 20
+			}
+		case variable && prefixInline && bytes > 768:
+			inline = 768 + 20
+		case variable && !prefixInline && bytes > 40:
+			inline = 20
+		}
+		size.Columns = append(size.Columns, RowSizeColumn{Name: c.Name, Bytes: bytes, InlineBytes: inline})
+		size.Bytes += bytes
+		size.InlineBytes += inline
+	}
+	bitmap := (nullable + 7) / 8
+	size.Bytes += bitmap
+	size.InlineBytes += bitmap
+	return size
+}
+
+// mysqlColumnSize returns the bytes a column counts toward the row size
+// limit, whether it is variable-length, and whether InnoDB always stores it
+// off-page (TEXT, BLOB and JSON).
+func mysqlColumnSize(c *Column, raw string, width int) (bytes int, variable, offPage bool) {
+	if raw == "" {
+		return portableColumnSize(c, width)
+	}
+	base := normalizeRawTypeBase(raw)
+	var args []int
+	if m := typeArgsRe.FindStringSubmatch(raw); m != nil && base != "ENUM" && base != "SET" {
+		for a := range strings.SplitSeq(m[1], ",") {
+			n, _ := strconv.Atoi(strings.TrimSpace(a))
+			args = append(args, n)
+		}
+	}
+	arg := func(i, def int) int {
+		if i < len(args) && args[i] > 0 {
+			return args[i]
+		}
+		return def
+	}
+	switch base {
+	case "TINYINT", "BOOL", "BOOLEAN", "YEAR":
+		return 1, false, false
+	case "SMALLINT":
+		return 2, false, false
+	case "MEDIUMINT", "DATE":
+		return 3, false, false
+	case "TIME":
+		return 3 + fractionalSecondsBytes(arg(0, 0)), false, false
+	case "INT", "INTEGER", "FLOAT":
+		return 4, false, false
+	case "BIGINT", "DOUBLE", "DOUBLE PRECISION", "REAL":
+		return 8, false, false
+	case "DATETIME":
+		return 5 + fractionalSecondsBytes(arg(0, 0)), false, false
+	case "TIMESTAMP":
+		return 4 + fractionalSecondsBytes(arg(0, 0)), false, false
+	case "DECIMAL", "DEC", "NUMERIC", "FIXED":
+		return decimalBytes(arg(0, 10), arg(1, 0)), false, false
+	case "BIT":
+		return (arg(0, 1) + 7) / 8, false, false
+	case "CHAR":
+		return arg(0, 1) * width, false, false
+	case "BINARY":
+		return arg(0, 1), false, false
+	case "VARCHAR":
+		return varLength(arg(0, 1) * width), true, false
+	case "VARBINARY":
+		return varLength(arg(0, 1)), true, false
+	case "TINYTEXT", "TINYBLOB":
+		return 9, true, true
+	case "TEXT", "BLOB":
+		return 10, true, true
+	case "MEDIUMTEXT", "MEDIUMBLOB":
+		return 11, true, true
+	case "LONGTEXT", "LONGBLOB", "JSON":
+		return 12, true, true
+	case "ENUM":
+		return enumBytes(len(c.EnumValues)), false, false
+	case "SET":
+		return setBytes(len(c.EnumValues)), false, false
+	default:
+		return portableColumnSize(c, width)
+	}
+}
+
+// portableColumnSize estimates a column from its portable type alone.
+func portableColumnSize(c *Column, width int) (bytes int, variable, offPage bool) {
+	switch c.Type {
+	case DataTypeBoolean:
+		return 1, false, false
+	case DataTypeInt:
+		return 4, false, false
+	case DataTypeFloat:
+		return 8, false, false
+	case DataTypeDatetime:
+		return 5, false, false
+	case DataTypeEnum:
+		return enumBytes(len(c.EnumValues)), false, false
+	case DataTypeSet:
+		return setBytes(len(c.EnumValues)), false, false
+	case DataTypeUUID:
+		return 36 * width, false, false
+	case DataTypeString:
+		return varLength(defaultStringLength * width), true, false
+	case DataTypeBinary:
+		return 10, true, true
+	default:
+		return 12, true, true
+	}
+}
+
+// varLength adds the one or two length bytes of a VARCHAR or VARBINARY.
+func varLength(n int) int {
+	if n > 255 {
+		return n + 2
+	}
+	return n + 1
+}
+
+// fractionalSecondsBytes is the extra storage of TIME, DATETIME and
+// TIMESTAMP with fsp fractional digits.
+func fractionalSecondsBytes(fsp int) int {
+	return (fsp + 1) / 2
+}
+
+// decimalBytes is the storage of DECIMAL(precision, scale): four bytes per
+// nine digits on each side of the point, plus the leftover digits.
+func decimalBytes(precision, scale int) int {
+	leftover := []int{0, 1, 1, 2, 2, 3, 3, 4, 4}
+	digits := func(n int) int { return n/9*4 + leftover[n%9] }
+	return digits(max(precision-scale, 0)) + digits(scale)
+}
+
+func enumBytes(n int) int {
+	if n > 255 {
+		return 2
+	}
+	return 1
+}
+
+func setBytes(n int) int {
+	switch b := (n + 7) / 8; b {
+	case 3:
+		return 4
+	case 5, 6, 7:
+		return 8
+	default:
+		return max(b, 1)
+	}
+}
+
+// rowSizeWarnings flags tables whose estimated row size exceeds the MySQL
+// or InnoDB limit when mysql or mariadb is targeted.
+func (t *Table) rowSizeWarnings(idx int, dialects []Dialect, declared Dialect) []Warning {
+	if !slices.Contains(dialects, DialectMySQL) && !slices.Contains(dialects, DialectMariaDB) {
+		return nil
+	}
+	size := t.MySQLRowSize(declared == DialectMySQL || declared == DialectMariaDB)
+	path := fmt.Sprintf("tables[%d]", idx)
+	if size.Bytes > MySQLMaxRowSize {
+		return []Warning{{
+			Code:    WarningRowSizeLimit,
+			Table:   t.Name,
+			Path:    path,
+			Message: fmt.Sprintf("table %q: estimated row size of %d bytes exceeds the MySQL limit of %d bytes; largest columns: %s", t.Name, size.Bytes, MySQLMaxRowSize, largestColumns(size.Columns, 3, func(c RowSizeColumn) int { return c.Bytes })),
+		}}
+	}
+	engine := "InnoDB"
+	if t.Options.MySQL != nil && t.Options.MySQL.Engine != "" {
+		engine = t.Options.MySQL.Engine
+	}
+	if strings.EqualFold(engine, "InnoDB") && size.InlineBytes > InnoDBMaxInlineRowSize {
+		return []Warning{{
+			Code:    WarningRowSizeLimit,
+			Table:   t.Name,
+			Path:    path,
+			Message: fmt.Sprintf("table %q: estimated in-page row size of %d bytes exceeds the InnoDB limit of %d bytes for ROW_FORMAT=%s; largest columns: %s", t.Name, size.InlineBytes, InnoDBMaxInlineRowSize, size.RowFormat, largestColumns(size.Columns, 3, func(c RowSizeColumn) int { return c.InlineBytes })),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMySQLRowSize(t *testing.T) {
+	table := &Table{
+		Name: "articles",
+		Columns: []*Column{
+			{Name: "id", Type: DataTypeInt, RawType: "BIGINT", PrimaryKey: true},
+			{Name: "title", Type: DataTypeString, RawType: "VARCHAR(200)", Nullable: true},
+			{Name: "slug", Type: DataTypeString, RawType: "CHAR(10)", Charset: "utf8mb4"},
+			{Name: "body", Type: DataTypeString, RawType: "MEDIUMTEXT", Nullable: true},
+			{Name: "price", Type: DataTypeFloat, RawType: "DECIMAL(12,2)"},
+			{Name: "published_at", Type: DataTypeDatetime, RawType: "DATETIME(6)"},
+			{Name: "flags", Type: DataTypeSet, RawType: "SET('a','b','c')", EnumValues: []string{"a", "b", "c"}},
+		},
+		Options: TableOptions{MySQL: &MySQLTableOptions{Charset: "latin1"}},
+	}
+	size := table.MySQLRowSize(true)
+
+	byName := make(map[string]RowSizeColumn)
+	for _, c := range size.Columns {
+		byName[c.Name] = c
+	}
+	assert.Equal(t, 8, byName["id"].Bytes)
+	assert.Equal(t, 201, byName["title"].Bytes, "latin1 plus one length byte")
+	assert.Equal(t, 40, byName["slug"].Bytes, "column charset wins over the table charset")
+	assert.Equal(t, 11, byName["body"].Bytes)
+	assert.Equal(t, 20, byName["body"].InlineBytes)
+	assert.Equal(t, 6, byName["price"].Bytes)
+	assert.Equal(t, 8, byName["published_at"].Bytes)
+	assert.Equal(t, 1, byName["flags"].Bytes)
+	assert.Equal(t, 8+201+40+11+6+8+1+1, size.Bytes, "includes a one-byte NULL bitmap")
+	assert.Equal(t, "DYNAMIC", size.RowFormat)
+}
+
+func TestLintRowSizeLimit(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt, RawType: "BIGINT", PrimaryKey: true},
+				{Name: "a", Type: DataTypeString, RawType: "VARCHAR(6000)"},
+				{Name: "b", Type: DataTypeString, RawType: "VARCHAR(6000)"},
+				{Name: "c", Type: DataTypeString, RawType: "VARCHAR(6000)"},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningRowSizeLimit, warnings[0].Code)
+	assert.Equal(t, "tables[0]", warnings[0].Path)
+	assert.Equal(t, `table "articles": estimated row size of 72014 bytes exceeds the MySQL limit of 65535 bytes; largest columns: a (24002 bytes), b (24002 bytes), c (24002 bytes)`, warnings[0].Message)
+
+	db.Tables[0].Options.MySQL = &MySQLTableOptions{Charset: "ascii"}
+	assert.Empty(t, db.Lint(), "18006 bytes fit, and DYNAMIC rows keep the VARCHARs off-page")
+
+	db.Tables[0].Options.MySQL.RowFormat = "compact"
+	columns := db.Tables[0].Columns
+	db.Tables[0].Columns = columns[:1:1]
+	for i := range 11 {
+		db.Tables[0].Columns = append(db.Tables[0].Columns, &Column{Name: string(rune('a' + i)), Type: DataTypeString, RawType: "VARCHAR(1000)"})
+	}
+	warnings = db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Message, "estimated in-page row size of 8676 bytes exceeds the InnoDB limit of 8126 bytes for ROW_FORMAT=COMPACT; largest columns: a (788 bytes)")
+
+	db.Tables[0].Options.MySQL.Engine = "MyISAM"
+	assert.Empty(t, db.Lint())
+
+	pg := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables:  []*Table{{Name: "articles", Columns: columns}},
+	}
+	require.NoError(t, pg.Validate())
+	assert.Empty(t, pg.Lint(), "mysql is not targeted")
+	assert.Empty(t, pg.Lint(DialectMySQL), "postgresql raw types are not read for mysql")
+}
@@ -14,6 +14,45 @@ type Database struct {
 	Dialect    *Dialect
 	Tables     []*Table
 	Validation *ValidationRules
+	// Extensions are the PostgreSQL extensions the schema depends on.
+	Extensions []*Extension
+	// EnumTypes are named enum types columns can use as their type.
+	EnumTypes []*EnumType
+	// Domains are named constrained types columns can use as their type.
+	Domains []*Domain
+}
+
+// EnumType is a named enum type (PostgreSQL CREATE TYPE ... AS ENUM).
+// Dialects with inline enums use its values on every column of the type.
+type EnumType struct {
+	Name    string   `json:"name"`
+	Values  []string `json:"values"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+// Domain is a named base type with an optional constraint (PostgreSQL
+// CREATE DOMAIN). Dialects without domains use the base type directly.
+type Domain struct {
+	Name string `json:"name"`
+	// BaseType is the underlying type, e.g. "text" or "numeric(12,2)".
+	BaseType string `json:"baseType"`
+	// NotNull forbids NULL values of the domain.
+	NotNull bool `json:"notNull,omitempty"`
+	// Default is the default value of the domain.
+	Default *string `json:"default,omitempty"`
+	// Check is the CHECK expression values must satisfy, written with VALUE.
+	Check   string `json:"check,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Extension is a PostgreSQL extension (CREATE EXTENSION) the schema needs.
+type Extension struct {
+	// Name is the extension name, e.g. "citext" or "uuid-ossp".
+	Name string `json:"name"`
+	// Schema is the schema the extension's objects are installed into.
+	Schema string `json:"schema,omitempty"`
+	// Version pins the extension version; empty means the default version.
+	Version string `json:"version,omitempty"`
 }
 
 // Dialect identifies a supported SQL dialect.
@@ -62,6 +101,16 @@ type ValidationRules struct {
 	MaxColumnNameLength         int    `json:"maxColumnNameLength,omitempty"`
 	AutoGenerateConstraintNames bool   `json:"autoGenerateConstraintNames,omitempty"`
 	AllowedNamePattern          string `json:"allowedNamePattern,omitempty"`
+	// StrictKeys turns unknown keys in the schema source into errors instead of warnings.
+	StrictKeys bool `json:"strictKeys,omitempty"`
+	// StrictDialectOptions rejects option groups for dialects other than the declared one.
+	StrictDialectOptions bool `json:"strictDialectOptions,omitempty"`
+	// TimestampPolicy restricts date-time columns to time-zone aware or naive types.
+	TimestampPolicy TimestampPolicy `json:"timestampPolicy,omitempty"`
+	// Naming holds the name patterns constraints and indexes are linted against.
+	Naming *NamingRules `json:"naming,omitempty"`
+	// RequirePrimaryKey flags tables without a primary key.
+	RequirePrimaryKey bool `json:"requirePrimaryKey,omitempty"`
 }
 
 // Table represents a table in the schema.
@@ -74,6 +123,15 @@ type Table struct {
 	Comment     string            `json:"comment,omitempty"`
 	Options     TableOptions      `json:"options"`
 	Timestamps  *TimestampsConfig `json:"timestamps,omitempty"`
+	// RowLevelSecurity enables row-level security on the table (PostgreSQL).
+	RowLevelSecurity bool `json:"rowLevelSecurity,omitempty"`
+	// Policies are the row-level security policies of the table (PostgreSQL).
+	Policies []*Policy `json:"policies,omitempty"`
+	// Dialects limits the table to the listed dialects; empty means all.
+	Dialects []Dialect `json:"dialects,omitempty"`
+	// AllowNoPrimaryKey exempts the table from require_primary_key, e.g. for
+	// append-only log tables.
+	AllowNoPrimaryKey bool `json:"allowNoPrimaryKey,omitempty"`
 }
 
 // TimestampsConfig controls automatic created_at / updated_at column injection.
@@ -285,7 +343,14 @@ type SQLServerTableOptions struct {
 	// MemoryOptimized enables In-Memory OLTP (memory-optimized table).
 	MemoryOptimized bool `json:"memory_optimized,omitempty"`
 	// SystemVersioning enables temporal table support (system-versioned).
+	// It requires PeriodStartColumn and PeriodEndColumn.
 	SystemVersioning bool `json:"system_versioning,omitempty"`
+	// PeriodStartColumn is the datetime2 column holding the row start time (PERIOD FOR SYSTEM_TIME).
+	PeriodStartColumn string `json:"period_start_column,omitempty"`
+	// PeriodEndColumn is the datetime2 column holding the row end time (PERIOD FOR SYSTEM_TIME).
+	PeriodEndColumn string `json:"period_end_column,omitempty"`
+	// HistoryTable names the history table (SYSTEM_VERSIONING = ON (HISTORY_TABLE = ...)).
+	HistoryTable string `json:"history_table,omitempty"`
 	// TextImageOn specifies the filegroup for TEXT/IMAGE/LOB data.
 	TextImageOn string `json:"textimage_on,omitempty"`
 	// LedgerTable enables the ledger (append-only) table feature in Azure SQL.
@@ -412,7 +477,8 @@ type Column struct {
 	// RefOnUpdate is the ON UPDATE referential action for an inline FK.
 	RefOnUpdate ReferentialAction `json:"refOnUpdate,omitempty"`
 
-	// EnumValues holds the allowed values when Type is "enum".
+	// EnumValues holds the allowed values when Type is "enum", or the
+	// allowed members when Type is "set".
 	// In TOML this is written as values = ["free", "pro", "enterprise"]
 	// which is cleaner and safer than embedding quotes in the type string.
 	EnumValues []string `json:"enumValues,omitempty"`
@@ -442,10 +508,18 @@ type Column struct {
 	// GenerationStorage controls whether the generated column is VIRTUAL or STORED.
 	GenerationStorage GenerationStorage `json:"generationStorage,omitempty"`
 
+	// UserType names the database-level enum type or domain the column was
+	// declared with. Type, RawType and EnumValues are resolved from it
+	// during validation.
+	UserType string `json:"userType,omitempty"`
+
 	// Invisible hides the column from SELECT * and some metadata views
 	// in dialects that support invisible/hidden columns (Oracle, MySQL 8+).
 	Invisible bool `json:"invisible,omitempty"`
 
+	// Dialects limits the column to the listed dialects; empty means all.
+	Dialects []Dialect `json:"dialects,omitempty"`
+
 	// Dialect-specific column option groups.
 	MySQL      *MySQLColumnOptions    `json:"mysql,omitempty"`
 	TiDB       *TiDBColumnOptions     `json:"tidb,omitempty"`
@@ -622,6 +696,7 @@ const (
 	DataTypeUUID     DataType = "uuid"
 	DataTypeBinary   DataType = "binary"
 	DataTypeEnum     DataType = "enum"
+	DataTypeSet      DataType = "set"
 	DataTypeUnknown  DataType = "unknown"
 )
 
@@ -655,8 +730,65 @@ type Constraint struct {
 	CheckExpression string `json:"checkExpression,omitempty"`
 	// Enforced controls whether a CHECK constraint is actively enforced (MySQL 8.0.16+).
 	Enforced bool `json:"enforced,omitempty"`
+
+	// Expressions lists SQL expressions a UNIQUE constraint covers in addition
+	// to Columns, e.g. "lower(email)" (PostgreSQL expression indexes, MySQL 8.0.13+
+	// functional key parts).
+	Expressions []string `json:"expressions,omitempty"`
+	// NullsNotDistinct makes a UNIQUE constraint treat NULLs as equal
+	// (PostgreSQL 15+ UNIQUE NULLS NOT DISTINCT).
+	NullsNotDistinct bool `json:"nullsNotDistinct,omitempty"`
+
+	// UsingMethod is the index access method backing an EXCLUDE constraint (e.g. "gist").
+	UsingMethod string `json:"usingMethod,omitempty"`
+	// Elements lists the expression/operator pairs of an EXCLUDE constraint.
+	Elements []ExclusionElement `json:"elements,omitempty"`
+}
+
+// ExclusionElement is one "expression WITH operator" item of an EXCLUDE
+// constraint (PostgreSQL).
+type ExclusionElement struct {
+	// Expression is a column name or expression, e.g. "room_id" or "tstzrange(starts_at, ends_at)".
+	Expression string `json:"expression"`
+	// Operator is the operator rows must not satisfy pairwise, e.g. "=" or "&&".
+	Operator string `json:"operator"`
+}
+
+// String returns the element in SQL form, e.g. "during WITH &&".
+func (e ExclusionElement) String() string {
+	return e.Expression + " WITH " + e.Operator
+}
+
+// Policy is a row-level security policy (PostgreSQL CREATE POLICY).
+type Policy struct {
+	// Name is the policy identifier, unique within its table.
+	Name string `json:"name"`
+	// Table is the table the policy applies to.
+	Table string `json:"table"`
+	// Command is the statement kind the policy applies to; empty means ALL.
+	Command PolicyCommand `json:"command,omitempty"`
+	// Roles lists the roles the policy applies to; empty means PUBLIC.
+	Roles []string `json:"roles,omitempty"`
+	// Using is the expression existing rows must satisfy to be visible.
+	Using string `json:"using,omitempty"`
+	// WithCheck is the expression new or updated rows must satisfy.
+	WithCheck string `json:"withCheck,omitempty"`
+	// Permissive combines the policy with OR (true) or, for a restrictive
+	// policy, with AND (false).
+	Permissive bool `json:"permissive"`
 }
 
+// PolicyCommand is an ENUM with the statement kinds a policy can apply to.
+type PolicyCommand string
+
+const (
+	PolicyAll    PolicyCommand = "ALL"
+	PolicySelect PolicyCommand = "SELECT"
+	PolicyInsert PolicyCommand = "INSERT"
+	PolicyUpdate PolicyCommand = "UPDATE"
+	PolicyDelete PolicyCommand = "DELETE"
+)
+
 // ConstraintType is an ENUM with all possible constraint types.
 type ConstraintType string
 
@@ -665,6 +797,7 @@ const (
 	ConstraintForeignKey ConstraintType = "FOREIGN KEY"
 	ConstraintUnique     ConstraintType = "UNIQUE"
 	ConstraintCheck      ConstraintType = "CHECK"
+	ConstraintExclusion  ConstraintType = "EXCLUDE"
 )
 
 // ReferentialAction is an ENUM with all possible column references after action.
@@ -693,6 +826,8 @@ type Index struct {
 	Comment string `json:"comment,omitempty"`
 	// Visibility controls whether the optimizer considers this index (VISIBLE or INVISIBLE).
 	Visibility IndexVisibility `json:"visibility,omitempty"`
+	// Dialects limits the index to the listed dialects; empty means all.
+	Dialects []Dialect `json:"dialects,omitempty"`
 }
 
 // ColumnIndex describes a single column reference within an index definition.
@@ -776,6 +911,16 @@ func (t *Table) FindIndex(name string) *Index {
 	return nil
 }
 
+// FindPolicy looks for a row-level security policy by name inside a table.
+func (t *Table) FindPolicy(name string) *Policy {
+	for _, p := range t.Policies {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
 // PrimaryKey returns the primary key constraint of the table.
 func (t *Table) PrimaryKey() *Constraint {
 	for _, c := range t.Constraints {
@@ -828,7 +973,7 @@ var normalizeDataTypeRules = []normalizeDataTypeRule{
 	{dataType: DataTypeDatetime, substrings: []string{"timestamp", "datetime"}},
 	{dataType: DataTypeFloat, substrings: []string{"double", "double precision", "numeric", "decimal", "real", "float"}},
 	{dataType: DataTypeBoolean, substrings: []string{"bool", "boolean", "tinyint(1)"}},
-	{dataType: DataTypeString, substrings: []string{"character varying", "varchar", "char", "text", "string", "set"}},
+	{dataType: DataTypeString, substrings: []string{"character varying", "varchar", "char", "text", "string"}},
 	{dataType: DataTypeInt, substrings: []string{"bigint", "smallint", "tinyint", "mediumint", "int"}},
 	{dataType: DataTypeDatetime, substrings: []string{"date", "time"}},
 	{dataType: DataTypeJSON, substrings: []string{"json"}},
@@ -837,9 +982,14 @@ var normalizeDataTypeRules = []normalizeDataTypeRule{
 
 // NormalizeDataType maps a raw SQL type string (e.g. "VARCHAR(255)") to one of
 // the portable DataType constants. The matching is case-insensitive and based
-// on substring containment using normalizeDataTypeRules.
+// on substring containment using normalizeDataTypeRules. SET is only
+// matched as the whole type, so "varchar(255) character set utf8mb4" stays a
+// string.
 func NormalizeDataType(rawType string) DataType {
 	lower := strings.ToLower(strings.TrimSpace(rawType))
+	if rest, ok := strings.CutPrefix(lower, "set"); ok && (rest == "" || strings.HasPrefix(strings.TrimSpace(rest), "(")) {
+		return DataTypeSet
+	}
 	for _, rule := range normalizeDataTypeRules {
 		for _, sub := range rule.substrings {
 			if strings.Contains(lower, sub) {
@@ -893,3 +1043,67 @@ func BuildEnumTypeRaw(values []string) string {
 	sb.WriteByte(')')
 	return sb.String()
 }
+
+// ParseEnumTypeRaw is the inverse of BuildEnumTypeRaw: it extracts the values
+// of a portable enum type string, e.g. "enum('free','pro')" -> ["free","pro"].
+// It reports false when raw is not an enum type with a value list.
+func ParseEnumTypeRaw(raw string) ([]string, bool) {
+	return parseValueListRaw(raw, "enum(")
+}
+
+// ParseSetTypeRaw extracts the members of a MySQL SET type string, e.g.
+// "set('read','write')" -> ["read","write"]. It reports false when raw is
+// not a set type with a value list.
+func ParseSetTypeRaw(raw string) ([]string, bool) {
+	return parseValueListRaw(raw, "set(")
+}
+
+// parseValueListRaw parses a quoted value list introduced by prefix (which
+// includes the opening parenthesis) and closed by ")".
+func parseValueListRaw(raw, prefix string) ([]string, bool) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) <= len(prefix) || !strings.EqualFold(raw[:len(prefix)], prefix) || raw[len(raw)-1] != ')' {
+		return nil, false
+	}
+	body := strings.TrimSpace(raw[len(prefix) : len(raw)-1])
+	if body == "" {
+		return nil, false
+	}
+
+	var values []string
+	for body != "" {
+		if body[0] != '\'' {
+			return nil, false
+		}
+		var sb strings.Builder
+		i := 1
+		for ; i < len(body); i++ {
+			if body[i] != '\'' {
+				sb.WriteByte(body[i])
+				continue
+			}
+			if i+1 < len(body) && body[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i++
+				continue
+			}
+			break
+		}
+		if i >= len(body) {
+			return nil, false
+		}
+		values = append(values, sb.String())
+		body = strings.TrimSpace(body[i+1:])
+		if body == "" {
+			break
+		}
+		if body[0] != ',' {
+			return nil, false
+		}
+		body = strings.TrimSpace(body[1:])
+		if body == "" {
+			return nil, false
+		}
+	}
+	return values, true
+}
@@ -227,7 +227,11 @@ func TestNormalizeDataType(t *testing.T) {
 		{"mediumtext", "MEDIUMTEXT", DataTypeString},
 		{"tinytext", "TINYTEXT", DataTypeString},
 		{"string", "STRING", DataTypeString},
-		{"set", "SET('x','y','z')", DataTypeString},
+		{"set", "SET('x','y','z')", DataTypeSet},
+		{"bare set", "set", DataTypeSet},
+		{"character set", "varchar(255) character set utf8mb4", DataTypeString},
+		{"charset suffix", "TEXT CHARACTER SET latin1", DataTypeString},
+		{"settings", "settings", DataTypeUnknown},
 
 		// Enum types
 		{"enum", "ENUM('a','b','c')", DataTypeEnum},
@@ -417,6 +421,39 @@ func TestBuildEnumTypeRaw(t *testing.T) {
 	})
 }
 
+func TestParseEnumTypeRaw(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		want := []string{"free", "it's", "a, b"}
+		values, ok := ParseEnumTypeRaw(BuildEnumTypeRaw(want))
+		assert.True(t, ok)
+		assert.Equal(t, want, values)
+	})
+
+	t.Run("case and whitespace", func(t *testing.T) {
+		values, ok := ParseEnumTypeRaw(" ENUM( 'a' , 'b' ) ")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"a", "b"}, values)
+	})
+
+	t.Run("not an enum list", func(t *testing.T) {
+		for _, raw := range []string{"enum", "enum()", "varchar(10)", "enum('a'", "enum(a,b)", "enum('a',)", "enum('a' 'b')"} {
+			_, ok := ParseEnumTypeRaw(raw)
+			assert.False(t, ok, raw)
+		}
+	})
+}
+
+func TestParseSetTypeRaw(t *testing.T) {
+	values, ok := ParseSetTypeRaw("SET('read', 'write')")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"read", "write"}, values)
+
+	for _, raw := range []string{"set", "set()", "enum('a')", "settings"} {
+		_, ok := ParseSetTypeRaw(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
 func TestAutoGenerateConstraintName(t *testing.T) {
 	t.Run("primary key", func(t *testing.T) {
 		name := AutoGenerateConstraintName(ConstraintPrimaryKey, "Users", []string{"id"}, "")
@@ -23,10 +23,22 @@ func (db *Database) Validate() error {
 		return err
 	}
 
+	if err := db.validateNamingRules(); err != nil {
+		return err
+	}
+
 	if err := db.validateTableUniqueness(); err != nil {
 		return err
 	}
 
+	if err := db.validateExtensions(); err != nil {
+		return err
+	}
+
+	if err := db.validateUserTypes(); err != nil {
+		return err
+	}
+
 	if err := db.validateAndSynthesizeConstraints(); err != nil {
 		return err
 	}
@@ -43,10 +55,22 @@ func (db *Database) Validate() error {
 		return err
 	}
 
+	if err := db.validateTimestampPolicy(); err != nil {
+		return err
+	}
+
+	if err := db.validateCharsets(); err != nil {
+		return err
+	}
+
 	if err := db.validateEnums(); err != nil {
 		return err
 	}
 
+	if err := db.validateDialectOptions(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
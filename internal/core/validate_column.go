@@ -1,8 +1,12 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // Validate checks a single column for structural correctness.
@@ -11,7 +15,7 @@ func (c *Column) Validate(rules *ValidationRules, nameRe *regexp.Regexp) error {
 		return fmt.Errorf("column %q: %w", c.Name, err)
 	}
 
-	if c.Type == "" && c.RawType == "" || c.Type == DataTypeUnknown {
+	if (c.Type == "" || c.Type == DataTypeUnknown) && c.RawType == "" {
 		return fmt.Errorf("column %q: type is empty", c.Name)
 	}
 
@@ -28,6 +32,10 @@ func (c *Column) Validate(rules *ValidationRules, nameRe *regexp.Regexp) error {
 		return fmt.Errorf("column %q: %w", c.Name, err)
 	}
 
+	if err := c.validateSetMembers(); err != nil {
+		return fmt.Errorf("column %q: %w", c.Name, err)
+	}
+
 	if c.References != "" {
 		if _, _, ok := ParseReferences(c.References); !ok {
 			return fmt.Errorf("column %q: invalid references %q: expected format \"table.column\"", c.Name, c.References)
@@ -38,5 +46,151 @@ func (c *Column) Validate(rules *ValidationRules, nameRe *regexp.Regexp) error {
 }
 
 func (c *Column) validateOptions() error {
+	if c.MSSQL != nil && c.MSSQL.DataMasking != nil {
+		if err := c.MSSQL.DataMasking.validate(c.Type); err != nil {
+			return fmt.Errorf("mssql: data_masking: %w", err)
+		}
+	}
+	if c.MSSQL != nil && c.MSSQL.AlwaysEncrypted != nil {
+		if err := c.validateAlwaysEncrypted(); err != nil {
+			return fmt.Errorf("mssql: always_encrypted: %w", err)
+		}
+	}
+	if c.Oracle != nil {
+		if err := c.Oracle.validateEncryption(); err != nil {
+			return fmt.Errorf("oracle: %w", err)
+		}
+	}
+	return nil
+}
+
+// oracleEncryptionAlgorithms are the algorithms Oracle TDE column
+// encryption accepts.
+var oracleEncryptionAlgorithms = []string{"AES256", "AES192", "AES128", "3DES168"}
+
+// validateEncryption checks the TDE column encryption options. The
+// algorithm and salt only apply to encrypted columns.
+func (o *OracleColumnOptions) validateEncryption() error {
+	if !o.Encrypt {
+		if o.EncryptionAlgorithm != "" || o.Salt != nil {
+			return errors.New("encryption_algorithm and salt require encrypt = true")
+		}
+		return nil
+	}
+	if o.EncryptionAlgorithm != "" && !slices.Contains(oracleEncryptionAlgorithms, strings.ToUpper(o.EncryptionAlgorithm)) {
+		return fmt.Errorf("invalid encryption_algorithm %q: expected %s", o.EncryptionAlgorithm, strings.Join(oracleEncryptionAlgorithms, ", "))
+	}
+	return nil
+}
+
+// alwaysEncryptedAlgorithm is the only algorithm SQL Server supports for
+// Always Encrypted columns.
+const alwaysEncryptedAlgorithm = "AEAD_AES_256_CBC_HMAC_SHA_256"
+
+// validateAlwaysEncrypted checks the Always Encrypted options and the column
+// features SQL Server cannot combine with client-side encryption.
+func (c *Column) validateAlwaysEncrypted() error {
+	o := c.MSSQL.AlwaysEncrypted
+	if strings.TrimSpace(o.ColumnEncryptionKey) == "" {
+		return errors.New("column_encryption_key is empty")
+	}
+	switch strings.ToUpper(o.EncryptionType) {
+	case "DETERMINISTIC":
+		if c.Type == DataTypeString && c.Collate != "" && !strings.HasSuffix(strings.ToUpper(c.Collate), "_BIN2") {
+			return fmt.Errorf("deterministic encryption of string columns requires a BIN2 collation, not %q", c.Collate)
+		}
+	case "RANDOMIZED":
+	default:
+		return fmt.Errorf("invalid encryption_type %q: expected DETERMINISTIC or RANDOMIZED", o.EncryptionType)
+	}
+	if o.Algorithm != "" && !strings.EqualFold(o.Algorithm, alwaysEncryptedAlgorithm) {
+		return fmt.Errorf("invalid algorithm %q: expected %s", o.Algorithm, alwaysEncryptedAlgorithm)
+	}
+	switch {
+	case c.AutoIncrement || c.IdentitySeed != 0 || c.IdentityIncrement != 0:
+		return errors.New("identity columns cannot be encrypted")
+	case c.IsGenerated:
+		return errors.New("computed columns cannot be encrypted")
+	case c.DefaultValue != nil:
+		return errors.New("encrypted columns cannot have a default")
+	}
+	return nil
+}
+
+// Shapes of the SQL Server dynamic data masking functions.
+var (
+	maskDefaultRe = regexp.MustCompile(`(?i)^default\(\s*\)$`)
+	maskEmailRe   = regexp.MustCompile(`(?i)^email\(\s*\)$`)
+	maskRandomRe  = regexp.MustCompile(`(?i)^random\(\s*(-?\d+(?:\.\d+)?)\s*,\s*(-?\d+(?:\.\d+)?)\s*\)$`)
+	maskPartialRe = regexp.MustCompile(`(?i)^partial\(\s*\d+\s*,\s*"[^"]*"\s*,\s*\d+\s*\)$`)
+)
+
+// validate checks that the masking function is one of default(), email(),
+// random(start, end) or partial(prefix, "padding", suffix), and that it
+// suits the column type: random() masks numbers, email() and partial()
+// mask strings.
+func (o *MSSQLDataMaskingOptions) validate(typ DataType) error {
+	fn := strings.TrimSpace(o.Function)
+	switch {
+	case fn == "":
+		return errors.New("function is empty")
+	case maskDefaultRe.MatchString(fn):
+		return nil
+	case maskEmailRe.MatchString(fn), maskPartialRe.MatchString(fn):
+		if typ != DataTypeString {
+			return fmt.Errorf("%s masks strings, not %s columns", fn, typ)
+		}
+		return nil
+	}
+	m := maskRandomRe.FindStringSubmatch(fn)
+	if m == nil {
+		return fmt.Errorf("invalid masking function %q: expected default(), email(), random(start, end) or partial(prefix, \"padding\", suffix)", fn)
+	}
+	if typ != DataTypeInt && typ != DataTypeFloat {
+		return fmt.Errorf("%s masks numbers, not %s columns", fn, typ)
+	}
+	start, _ := strconv.ParseFloat(m[1], 64)
+	end, _ := strconv.ParseFloat(m[2], 64)
+	if start > end {
+		return fmt.Errorf("%s: start is greater than end", fn)
+	}
+	return nil
+}
+
+// maxSetMembers is the most members a MySQL SET column can have.
+const maxSetMembers = 64
+
+// validateSetMembers checks the members of a SET column and that its default
+// is a comma-separated subset of them.
+func (c *Column) validateSetMembers() error {
+	if c.Type != DataTypeSet {
+		return nil
+	}
+	if len(c.EnumValues) == 0 {
+		return errors.New("set column has no values")
+	}
+	if len(c.EnumValues) > maxSetMembers {
+		return fmt.Errorf("set column has %d values; at most %d are allowed", len(c.EnumValues), maxSetMembers)
+	}
+	for i, v := range c.EnumValues {
+		if strings.Contains(v, ",") {
+			return fmt.Errorf("set value %q must not contain a comma", v)
+		}
+		if slices.Contains(c.EnumValues[:i], v) {
+			return fmt.Errorf("duplicate set value %q", v)
+		}
+	}
+	if c.DefaultValue == nil {
+		return nil
+	}
+	def := strings.Trim(*c.DefaultValue, "'")
+	if def == "" {
+		return nil
+	}
+	for member := range strings.SplitSeq(def, ",") {
+		if !slices.Contains(c.EnumValues, member) {
+			return fmt.Errorf("default %q is not a subset of the set values: %q is not a member", *c.DefaultValue, member)
+		}
+	}
 	return nil
 }
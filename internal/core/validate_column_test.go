@@ -170,3 +170,181 @@ func TestValidateDatabaseColumnAndConstraintPrimaryKeyBothPresent(t *testing.T)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "primary key declared on both")
 }
+
+func TestValidateSetColumn(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "perms", Type: DataTypeSet, EnumValues: []string{"read", "write", "admin"}, DefaultValue: new("read,write")},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint())
+}
+
+func TestValidateSetColumnErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		col  *Column
+		want string
+	}{
+		{name: "no values", col: &Column{Name: "perms", Type: DataTypeSet}, want: "set column has no values"},
+		{name: "duplicate value", col: &Column{Name: "perms", Type: DataTypeSet, EnumValues: []string{"read", "read"}}, want: `duplicate set value "read"`},
+		{name: "comma in value", col: &Column{Name: "perms", Type: DataTypeSet, EnumValues: []string{"a,b"}}, want: "must not contain a comma"},
+		{
+			name: "default outside values",
+			col:  &Column{Name: "perms", Type: DataTypeSet, EnumValues: []string{"read", "write"}, DefaultValue: new("read,delete")},
+			want: `default "read,delete" is not a subset of the set values: "delete" is not a member`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectMySQL),
+				Tables:  []*Table{{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt}, tt.col}}},
+			}
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintSetColumnUnsupportedByDialect(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables:  []*Table{{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt}, {Name: "perms", Type: DataTypeSet, EnumValues: []string{"read"}}}}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningDialectUnsupported, warnings[0].Code)
+	assert.Equal(t, "tables[0].columns[1].type", warnings[0].Path)
+	assert.Empty(t, db.Lint(DialectMariaDB))
+}
+
+func TestValidateDataMasking(t *testing.T) {
+	tests := []struct {
+		typ  DataType
+		fn   string
+		want string
+	}{
+		{typ: DataTypeDatetime, fn: "default()"},
+		{typ: DataTypeString, fn: "email()"},
+		{typ: DataTypeString, fn: `partial(1, "XXXX", 2)`},
+		{typ: DataTypeString, fn: `PARTIAL(0,"",0)`},
+		{typ: DataTypeInt, fn: "random(1, 100)"},
+		{typ: DataTypeFloat, fn: "random(-1.5,1.5)"},
+		{typ: DataTypeString, fn: " ", want: "mssql: data_masking: function is empty"},
+		{typ: DataTypeString, fn: "hash()", want: `invalid masking function "hash()"`},
+		{typ: DataTypeString, fn: "partial(1, XXXX, 2)", want: "invalid masking function"},
+		{typ: DataTypeString, fn: "random(1, 10)", want: "random(1, 10) masks numbers, not string columns"},
+		{typ: DataTypeInt, fn: "email()", want: "email() masks strings, not int columns"},
+		{typ: DataTypeInt, fn: "random(10, 1)", want: "start is greater than end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fn, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectMSSQL),
+				Tables: []*Table{{
+					Name: "users",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt},
+						{Name: "secret", Type: tt.typ, MSSQL: &MSSQLColumnOptions{DataMasking: &MSSQLDataMaskingOptions{Function: tt.fn}}},
+					},
+				}},
+			}
+			err := db.Validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestValidateAlwaysEncrypted(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Column)
+		want   string
+	}{
+		{name: "deterministic", modify: func(c *Column) { c.Collate = "Latin1_General_BIN2" }},
+		{name: "randomized", modify: func(c *Column) {
+			c.MSSQL.AlwaysEncrypted.EncryptionType, c.MSSQL.AlwaysEncrypted.Algorithm = "randomized", ""
+		}},
+		{name: "missing key", modify: func(c *Column) { c.MSSQL.AlwaysEncrypted.ColumnEncryptionKey = "" }, want: "mssql: always_encrypted: column_encryption_key is empty"},
+		{name: "encryption type", modify: func(c *Column) { c.MSSQL.AlwaysEncrypted.EncryptionType = "AES" }, want: `invalid encryption_type "AES"`},
+		{name: "algorithm", modify: func(c *Column) { c.MSSQL.AlwaysEncrypted.Algorithm = "AES_256" }, want: `invalid algorithm "AES_256"`},
+		{name: "collation", modify: func(c *Column) { c.Collate = "Latin1_General_CI_AS" }, want: "requires a BIN2 collation"},
+		{name: "identity", modify: func(c *Column) { c.Type, c.IdentitySeed = DataTypeInt, 1 }, want: "identity columns cannot be encrypted"},
+		{name: "computed", modify: func(c *Column) { c.IsGenerated, c.GenerationExpression = true, "'x'" }, want: "computed columns cannot be encrypted"},
+		{name: "default", modify: func(c *Column) { c.DefaultValue = new("''") }, want: "encrypted columns cannot have a default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := &Column{Name: "ssn", Type: DataTypeString, MSSQL: &MSSQLColumnOptions{AlwaysEncrypted: &MSSQLAlwaysEncryptedOptions{
+				ColumnEncryptionKey: "cek_ssn",
+				EncryptionType:      "DETERMINISTIC",
+				Algorithm:           "AEAD_AES_256_CBC_HMAC_SHA_256",
+			}}}
+			tt.modify(col)
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectMSSQL),
+				Tables:  []*Table{{Name: "users", Columns: []*Column{{Name: "id", Type: DataTypeInt}, col}}},
+			}
+			err := db.Validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestValidateOracleEncryption(t *testing.T) {
+	tests := []struct {
+		name string
+		opts OracleColumnOptions
+		want string
+	}{
+		{name: "encrypted", opts: OracleColumnOptions{Encrypt: true, EncryptionAlgorithm: "aes256", Salt: new(false)}},
+		{name: "default algorithm", opts: OracleColumnOptions{Encrypt: true}},
+		{name: "unknown algorithm", opts: OracleColumnOptions{Encrypt: true, EncryptionAlgorithm: "AES512"}, want: `oracle: invalid encryption_algorithm "AES512": expected AES256, AES192, AES128, 3DES168`},
+		{name: "algorithm without encrypt", opts: OracleColumnOptions{EncryptionAlgorithm: "AES256"}, want: "require encrypt = true"},
+		{name: "salt without encrypt", opts: OracleColumnOptions{Salt: new(true)}, want: "require encrypt = true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectOracle),
+				Tables: []*Table{{
+					Name:    "users",
+					Columns: []*Column{{Name: "id", Type: DataTypeInt}, {Name: "ssn", Type: DataTypeString, Oracle: &tt.opts}},
+				}},
+			}
+			err := db.Validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
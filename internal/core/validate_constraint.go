@@ -2,11 +2,12 @@ package core
 
 import (
 	"fmt"
+	"strings"
 )
 
 // validateConstraints checks for duplicate constraint names, missing columns,
-// and incomplete FK definitions.
-func (t *Table) validateConstraints() error {
+// and incomplete FK definitions. CHECK expressions are read as written for d.
+func (t *Table) validateConstraints(d Dialect) error {
 	seen := make(map[string]bool, len(t.Constraints))
 	for _, con := range t.Constraints {
 		if con.Name == "" {
@@ -22,7 +23,7 @@ func (t *Table) validateConstraints() error {
 	}
 
 	for _, con := range t.Constraints {
-		if err := t.validateConstraintColumns(con); err != nil {
+		if err := t.validateConstraintColumns(con, d); err != nil {
 			return err
 		}
 	}
@@ -31,13 +32,32 @@ func (t *Table) validateConstraints() error {
 }
 
 // validateConstraintColumns verifies a single constraint's columns exist, are
-// non-empty (except CHECK), and that FK constraints have referenced_table and
-// referenced_columns.
-func (t *Table) validateConstraintColumns(con *Constraint) error {
-	if con.Type == ConstraintCheck {
+// non-empty (except CHECK and EXCLUDE), and that FK constraints have
+// referenced_table and referenced_columns.
+func (t *Table) validateConstraintColumns(con *Constraint, d Dialect) error {
+	if con.Type != ConstraintUnique && (len(con.Expressions) > 0 || con.NullsNotDistinct) {
+		return fmt.Errorf("constraint %q (%s): expressions and nulls_not_distinct are only allowed on UNIQUE constraints", con.Name, con.Type)
+	}
+	if con.Type != ConstraintExclusion && (len(con.Elements) > 0 || con.UsingMethod != "") {
+		return fmt.Errorf("constraint %q (%s): elements and using are only allowed on EXCLUDE constraints", con.Name, con.Type)
+	}
+	switch con.Type {
+	case ConstraintCheck:
+		for _, name := range ExpressionColumns(con.CheckExpression, d) {
+			if t.FindColumn(name) == nil {
+				return fmt.Errorf("constraint %q: check expression references nonexistent column %q", con.Name, name)
+			}
+		}
 		return nil
+	case ConstraintExclusion:
+		return validateExclusionElements(con)
 	}
-	if len(con.Columns) == 0 {
+	for _, expr := range con.Expressions {
+		if strings.TrimSpace(expr) == "" {
+			return fmt.Errorf("constraint %q has an empty expression", con.Name)
+		}
+	}
+	if len(con.Columns) == 0 && len(con.Expressions) == 0 {
 		return fmt.Errorf("constraint %q (%s) has no columns", con.Name, con.Type)
 	}
 	for _, colName := range con.Columns {
@@ -56,6 +76,35 @@ func (t *Table) validateConstraintColumns(con *Constraint) error {
 	return nil
 }
 
+// ReferenceError reports a foreign key that points at a table or column that
+// does not exist.
+type ReferenceError struct {
+	// Table is the table declaring the foreign key.
+	Table string
+	// Constraint is the name of the foreign key constraint.
+	Constraint string
+	// Missing describes the object that could not be resolved, e.g. `table "orgs"`.
+	Missing string
+}
+
+func (e *ReferenceError) Error() string {
+	return fmt.Sprintf("table %q, constraint %q: references non-existent %s", e.Table, e.Constraint, e.Missing)
+}
+
+// validateExclusionElements checks that an EXCLUDE constraint has at least
+// one complete element. Expressions are not resolved against the columns.
+func validateExclusionElements(con *Constraint) error {
+	if len(con.Elements) == 0 {
+		return fmt.Errorf("exclusion constraint %q has no elements", con.Name)
+	}
+	for i, e := range con.Elements {
+		if strings.TrimSpace(e.Expression) == "" || strings.TrimSpace(e.Operator) == "" {
+			return fmt.Errorf("exclusion constraint %q: element %d needs both an expression and an operator", con.Name, i)
+		}
+	}
+	return nil
+}
+
 func (db *Database) validateForeignKeys() error {
 	for _, t := range db.Tables {
 		for _, con := range t.Constraints {
@@ -64,19 +113,19 @@ func (db *Database) validateForeignKeys() error {
 			}
 			refTable := db.FindTable(con.ReferencedTable)
 			if refTable == nil {
-				return fmt.Errorf("table %q, constraint %q: references non-existent table %q",
-					t.Name, con.Name, con.ReferencedTable)
+				return &ReferenceError{Table: t.Name, Constraint: con.Name,
+					Missing: fmt.Sprintf("table %q", con.ReferencedTable)}
 			}
 			for _, refColName := range con.ReferencedColumns {
 				if refTable.FindColumn(refColName) == nil {
-					return fmt.Errorf("table %q, constraint %q: references non-existent column %q in table %q",
-						t.Name, con.Name, refColName, con.ReferencedTable)
+					return &ReferenceError{Table: t.Name, Constraint: con.Name,
+						Missing: fmt.Sprintf("column %q in table %q", refColName, con.ReferencedTable)}
 				}
 			}
 			for _, colName := range con.Columns {
 				if t.FindColumn(colName) == nil {
-					return fmt.Errorf("table %q, constraint %q: references non-existent column %q",
-						t.Name, con.Name, colName)
+					return &ReferenceError{Table: t.Name, Constraint: con.Name,
+						Missing: fmt.Sprintf("column %q", colName)}
 				}
 			}
 		}
@@ -220,3 +220,230 @@ func TestValidateDatabaseForeignKeyNotExisting(t *testing.T) {
 		assert.Contains(t, err.Error(), `references non-existent column "uuid" in table "users"`)
 	})
 }
+
+func TestValidateUniqueExpressions(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "tenant_id", Type: DataTypeInt, Nullable: true},
+				{Name: "email", Type: DataTypeString},
+			},
+			Constraints: []*Constraint{{
+				Name:             "uq_users_email_ci",
+				Type:             ConstraintUnique,
+				Columns:          []string{"tenant_id"},
+				Expressions:      []string{"lower(email)"},
+				NullsNotDistinct: true,
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint())
+}
+
+func TestValidateUniqueExpressionsOnly(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "tenant_id", Type: DataTypeInt, Nullable: true},
+				{Name: "email", Type: DataTypeString},
+			},
+			Constraints: []*Constraint{{
+				Name:        "uq_users_email_ci",
+				Type:        ConstraintUnique,
+				Expressions: []string{"(lower(email))"},
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+}
+
+func TestValidateUniqueExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		con  *Constraint
+		want string
+	}{
+		{
+			name: "expressions on foreign key",
+			con: &Constraint{Name: "fk_x", Type: ConstraintForeignKey, Columns: []string{"tenant_id"},
+				ReferencedTable: "users", ReferencedColumns: []string{"id"}, Expressions: []string{"lower(email)"}},
+			want: "only allowed on UNIQUE constraints",
+		},
+		{
+			name: "nulls not distinct on primary key",
+			con:  &Constraint{Name: "pk_x", Type: ConstraintPrimaryKey, Columns: []string{"id"}, NullsNotDistinct: true},
+			want: "only allowed on UNIQUE constraints",
+		},
+		{
+			name: "empty expression",
+			con:  &Constraint{Name: "uq_x", Type: ConstraintUnique, Expressions: []string{" "}},
+			want: `constraint "uq_x" has an empty expression`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectPostgreSQL),
+				Tables: []*Table{{
+					Name: "users",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt},
+						{Name: "tenant_id", Type: DataTypeInt, Nullable: true},
+						{Name: "email", Type: DataTypeString},
+					},
+					Constraints: []*Constraint{tt.con},
+				}},
+			}
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintUniqueFeaturesUnsupportedByDialect(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMariaDB),
+		Tables: []*Table{{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "tenant_id", Type: DataTypeInt, Nullable: true},
+				{Name: "email", Type: DataTypeString},
+			},
+			Constraints: []*Constraint{{
+				Name:             "uq_users_email_ci",
+				Type:             ConstraintUnique,
+				Expressions:      []string{"lower(email)"},
+				NullsNotDistinct: true,
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, WarningDialectUnsupported, warnings[0].Code)
+	assert.Equal(t, "tables[0].constraints[0].expressions", warnings[0].Path)
+	assert.Equal(t, `table "users", constraint "uq_users_email_ci": unique expressions are not supported by mariadb`, warnings[0].Message)
+	assert.Equal(t, "tables[0].constraints[0].nulls_not_distinct", warnings[1].Path)
+
+	assert.Empty(t, db.Lint(DialectPostgreSQL), "supported by an extra target")
+}
+
+func TestValidateExclusionConstraint(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{{
+			Name: "bookings",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "room_id", Type: DataTypeInt},
+				{Name: "during", Type: DataTypeString},
+			},
+			Constraints: []*Constraint{{
+				Name:        "ex_bookings_room_during",
+				Type:        ConstraintExclusion,
+				UsingMethod: "gist",
+				Elements: []ExclusionElement{
+					{Expression: "room_id", Operator: "="},
+					{Expression: "during", Operator: "&&"},
+				},
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint())
+	assert.Equal(t, "during WITH &&", db.Tables[0].Constraints[0].Elements[1].String())
+}
+
+func TestValidateExclusionConstraintErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		con  *Constraint
+		want string
+	}{
+		{
+			name: "no elements",
+			con:  &Constraint{Name: "ex_x", Type: ConstraintExclusion, UsingMethod: "gist"},
+			want: `exclusion constraint "ex_x" has no elements`,
+		},
+		{
+			name: "missing operator",
+			con: &Constraint{Name: "ex_x", Type: ConstraintExclusion,
+				Elements: []ExclusionElement{{Expression: "room_id"}}},
+			want: "element 0 needs both an expression and an operator",
+		},
+		{
+			name: "elements on unique",
+			con: &Constraint{Name: "uq_x", Type: ConstraintUnique, Columns: []string{"room_id"},
+				Elements: []ExclusionElement{{Expression: "room_id", Operator: "="}}},
+			want: "only allowed on EXCLUDE constraints",
+		},
+		{
+			name: "using on check",
+			con:  &Constraint{Name: "chk_x", Type: ConstraintCheck, CheckExpression: "room_id > 0", UsingMethod: "gist"},
+			want: "only allowed on EXCLUDE constraints",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectPostgreSQL),
+				Tables: []*Table{{
+					Name: "bookings",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt},
+						{Name: "room_id", Type: DataTypeInt},
+						{Name: "during", Type: DataTypeString},
+					},
+					Constraints: []*Constraint{tt.con},
+				}},
+			}
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintExclusionConstraintUnsupportedByDialect(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{{
+			Name: "bookings",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "room_id", Type: DataTypeInt},
+				{Name: "during", Type: DataTypeString},
+			},
+			Constraints: []*Constraint{{
+				Name:     "ex_bookings_room_during",
+				Type:     ConstraintExclusion,
+				Elements: []ExclusionElement{{Expression: "room_id", Operator: "="}},
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningDialectUnsupported, warnings[0].Code)
+	assert.Equal(t, `table "bookings", constraint "ex_bookings_room_during": EXCLUDE constraints are only supported by postgresql, not mysql`, warnings[0].Message)
+
+	assert.Empty(t, db.Lint(DialectPostgreSQL))
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// mysqlFamily lists the dialects that read MySQLTableOptions and
+// MySQLColumnOptions.
+var mysqlFamily = []Dialect{DialectMySQL, DialectMariaDB, DialectTiDB}
+
+// optionGroup describes a dialect-specific option group and whether it is set.
+type optionGroup struct {
+	name     string
+	dialects []Dialect
+	set      bool
+}
+
+func (o *TableOptions) groups() []optionGroup {
+	return []optionGroup{
+		{name: "mysql", dialects: mysqlFamily, set: o.MySQL != nil},
+		{name: "tidb", dialects: []Dialect{DialectTiDB}, set: o.TiDB != nil},
+		{name: "postgresql", dialects: []Dialect{DialectPostgreSQL}, set: o.PostgreSQL != nil},
+		{name: "oracle", dialects: []Dialect{DialectOracle}, set: o.Oracle != nil},
+		{name: "sqlserver", dialects: []Dialect{DialectMSSQL}, set: o.SQLServer != nil},
+		{name: "db2", dialects: []Dialect{DialectDB2}, set: o.DB2 != nil},
+		{name: "snowflake", dialects: []Dialect{DialectSnowflake}, set: o.Snowflake != nil},
+		{name: "sqlite", dialects: []Dialect{DialectSQLite}, set: o.SQLite != nil},
+		{name: "mariadb", dialects: []Dialect{DialectMariaDB}, set: o.MariaDB != nil},
+	}
+}
+
+func (c *Column) optionGroups() []optionGroup {
+	return []optionGroup{
+		{name: "mysql", dialects: mysqlFamily, set: c.MySQL != nil},
+		{name: "tidb", dialects: []Dialect{DialectTiDB}, set: c.TiDB != nil},
+		{name: "postgresql", dialects: []Dialect{DialectPostgreSQL}, set: c.PostgreSQL != nil},
+		{name: "oracle", dialects: []Dialect{DialectOracle}, set: c.Oracle != nil},
+		{name: "mssql", dialects: []Dialect{DialectMSSQL}, set: c.MSSQL != nil},
+		{name: "db2", dialects: []Dialect{DialectDB2}, set: c.DB2 != nil},
+		{name: "sqlite", dialects: []Dialect{DialectSQLite}, set: c.SQLite != nil},
+	}
+}
+
+// strayGroups returns the names of the set groups that none of the given
+// dialects read.
+func strayGroups(groups []optionGroup, dialects []Dialect) []string {
+	var stray []string
+	for _, g := range groups {
+		if !g.set {
+			continue
+		}
+		used := slices.ContainsFunc(g.dialects, func(d Dialect) bool {
+			return slices.Contains(dialects, d)
+		})
+		if !used {
+			stray = append(stray, g.name)
+		}
+	}
+	return stray
+}
+
+// strayDialectOptions reports table and column option groups that are set
+// but ignored by every dialect in dialects. idx is the table's position in
+// Database.Tables and is used to build the warning paths.
+func (t *Table) strayDialectOptions(idx int, dialects []Dialect) []Warning {
+	targets := dialectList(dialects)
+
+	var warnings []Warning
+	for _, g := range strayGroups(t.Options.groups(), dialects) {
+		warnings = append(warnings, Warning{
+			Code:    WarningStrayDialectOptions,
+			Table:   t.Name,
+			Path:    fmt.Sprintf("tables[%d].options.%s", idx, g),
+			Message: fmt.Sprintf("table %q: %s table options are set but %s is not a target dialect (targets: %s)", t.Name, g, g, targets),
+		})
+	}
+	for i, col := range t.Columns {
+		for _, g := range strayGroups(col.optionGroups(), dialects) {
+			warnings = append(warnings, Warning{
+				Code:    WarningStrayDialectOptions,
+				Table:   t.Name,
+				Object:  col.Name,
+				Path:    fmt.Sprintf("tables[%d].columns[%d].%s", idx, i, g),
+				Message: fmt.Sprintf("table %q, column %q: %s column options are set but %s is not a target dialect (targets: %s)", t.Name, col.Name, g, g, targets),
+			})
+		}
+	}
+	return warnings
+}
+
+// validateDialectOptions rejects option groups for dialects other than the
+// declared one when [validation] strict_dialect_options is enabled.
+func (db *Database) validateDialectOptions() error {
+	if db.Validation == nil || !db.Validation.StrictDialectOptions {
+		return nil
+	}
+	dialects := []Dialect{*db.Dialect}
+	var errs []error
+	for i, table := range db.Tables {
+		for _, w := range table.strayDialectOptions(i, dialects) {
+			errs = append(errs, &WarningError{Warning: w})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func dialectList(dialects []Dialect) string {
+	names := make([]string, len(dialects))
+	for i, d := range dialects {
+		names[i] = string(d)
+	}
+	return strings.Join(names, ", ")
+}
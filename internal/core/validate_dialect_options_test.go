@@ -0,0 +1,148 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintStrayDialectOptions(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{
+			{
+				Name: "users",
+				Options: TableOptions{
+					MySQL:      &MySQLTableOptions{Engine: "InnoDB"},
+					PostgreSQL: &PostgreSQLTableOptions{Schema: "public"},
+				},
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "email", Type: DataTypeString, MSSQL: &MSSQLColumnOptions{Sparse: true}},
+				},
+			},
+		},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 2)
+
+	assert.Equal(t, WarningStrayDialectOptions, warnings[0].Code)
+	assert.Equal(t, "users", warnings[0].Table)
+	assert.Equal(t, "tables[0].options.postgresql", warnings[0].Path)
+	assert.Equal(t, `table "users": postgresql table options are set but postgresql is not a target dialect (targets: mysql)`, warnings[0].Message)
+
+	assert.Equal(t, "email", warnings[1].Object)
+	assert.Equal(t, "tables[0].columns[1].mssql", warnings[1].Path)
+
+	assert.Empty(t, db.Lint(DialectPostgreSQL, DialectMSSQL), "extra targets use the options")
+}
+
+func TestLintMySQLOptionsAllowedForMySQLFamily(t *testing.T) {
+	for _, d := range []Dialect{DialectMariaDB, DialectTiDB} {
+		db := &Database{
+			Name:    "app",
+			Dialect: new(d),
+			Tables: []*Table{{
+				Name:    "users",
+				Options: TableOptions{MySQL: &MySQLTableOptions{Engine: "InnoDB"}},
+				Columns: []*Column{{Name: "id", Type: DataTypeInt, PrimaryKey: true}},
+			}},
+		}
+		require.NoError(t, db.Validate())
+		assert.Empty(t, db.Lint(), "dialect %s", d)
+	}
+}
+
+func TestValidateStrictDialectOptions(t *testing.T) {
+	db := &Database{
+		Name:       "app",
+		Dialect:    new(DialectMySQL),
+		Validation: &ValidationRules{StrictDialectOptions: true},
+		Tables: []*Table{
+			{
+				Name: "users",
+				Options: TableOptions{
+					MySQL:      &MySQLTableOptions{Engine: "InnoDB"},
+					PostgreSQL: &PostgreSQLTableOptions{Schema: "public"},
+				},
+				Columns: []*Column{
+					{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+					{Name: "email", Type: DataTypeString, MSSQL: &MSSQLColumnOptions{Sparse: true}},
+				},
+			},
+		},
+	}
+
+	err := db.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "postgresql table options are set")
+	assert.Contains(t, err.Error(), `column "email": mssql column options are set`)
+}
+
+func TestValidateTiDBStatsOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TiDBTableOptions
+		want string
+	}{
+		{name: "valid list", opts: TiDBTableOptions{StatsColsChoice: "LIST", StatsColList: "id, email", StatsSampleRate: 1}},
+		{name: "valid default", opts: TiDBTableOptions{StatsColsChoice: "default", StatsBuckets: 256}},
+		{name: "sample rate above one", opts: TiDBTableOptions{StatsSampleRate: 1.5}, want: "stats_sample_rate 1.5 must be in (0, 1]"},
+		{name: "negative sample rate", opts: TiDBTableOptions{StatsSampleRate: -0.1}, want: "must be in (0, 1]"},
+		{name: "invalid choice", opts: TiDBTableOptions{StatsColsChoice: "SOME"}, want: `invalid stats_cols_choice "SOME"`},
+		{name: "list without columns", opts: TiDBTableOptions{StatsColsChoice: "LIST"}, want: "requires stats_col_list"},
+		{name: "columns without list", opts: TiDBTableOptions{StatsColList: "id"}, want: `stats_col_list requires stats_cols_choice = "LIST"`},
+		{name: "unknown column", opts: TiDBTableOptions{StatsColsChoice: "LIST", StatsColList: "id,nickname"}, want: `stats_col_list references nonexistent column "nickname"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectTiDB),
+				Tables: []*Table{{
+					Name:    "users",
+					Columns: []*Column{{Name: "id", Type: DataTypeInt}, {Name: "email", Type: DataTypeString}},
+					Options: TableOptions{TiDB: &tt.opts},
+				}},
+			}
+			err := db.Validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintSQLiteStrictRawTypes(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectSQLite),
+		Tables: []*Table{{
+			Name: "events",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt, RawType: "INTEGER", PrimaryKey: true},
+				{Name: "payload", Type: DataTypeString, RawType: "text"},
+				{Name: "title", Type: DataTypeString, RawType: "VARCHAR(255)"},
+				{Name: "created_at", Type: DataTypeDatetime},
+			},
+			Options: TableOptions{SQLite: &SQLiteTableOptions{Strict: true}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningDialectUnsupported, warnings[0].Code)
+	assert.Equal(t, "tables[0].columns[2].raw_type", warnings[0].Path)
+	assert.Equal(t, `table "events", column "title": raw type "VARCHAR(255)" is not allowed in a STRICT table; use one of INT, INTEGER, REAL, TEXT, BLOB, ANY`, warnings[0].Message)
+
+	db.Tables[0].Options.SQLite.Strict = false
+	assert.Empty(t, db.Lint())
+}
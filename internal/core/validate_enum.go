@@ -49,7 +49,7 @@ func (c *Column) validateType(table *Table) error {
 	switch c.Type {
 	case DataTypeString, DataTypeInt, DataTypeFloat, DataTypeBoolean,
 		DataTypeDatetime, DataTypeJSON, DataTypeUUID, DataTypeBinary,
-		DataTypeEnum, DataTypeUnknown:
+		DataTypeEnum, DataTypeSet, DataTypeUnknown:
 		return nil
 	default:
 		return fmt.Errorf("table %q, column %q: invalid type %q", table.Name, c.Name, c.Type)
@@ -90,7 +90,7 @@ func (c *Column) validateIdentity(table *Table) error {
 
 func (con *Constraint) validateEnums(table *Table) error {
 	switch con.Type {
-	case ConstraintPrimaryKey, ConstraintForeignKey, ConstraintUnique, ConstraintCheck:
+	case ConstraintPrimaryKey, ConstraintForeignKey, ConstraintUnique, ConstraintCheck, ConstraintExclusion:
 	default:
 		return fmt.Errorf("table %q, constraint %q: invalid constraint type %q", table.Name, con.Name, con.Type)
 	}
@@ -96,6 +96,8 @@ func TestValidateEnumsColumnGeneration(t *testing.T) {
 						Name: "users",
 						Columns: []*Column{
 							{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+							{Name: "first_name", Type: DataTypeString},
+							{Name: "last_name", Type: DataTypeString},
 							{
 								Name:                 "full_name",
 								Type:                 DataTypeString,
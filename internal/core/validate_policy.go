@@ -0,0 +1,47 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// validatePolicies checks row-level security policy names, commands and the
+// expressions each command accepts.
+func (t *Table) validatePolicies() error {
+	seen := make(map[string]bool, len(t.Policies))
+	for _, p := range t.Policies {
+		if err := validateName(p.Name, nil, nil, false); err != nil {
+			return fmt.Errorf("table %q, policy %q: %w", t.Name, p.Name, err)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("table %q: duplicate policy name %q", t.Name, p.Name)
+		}
+		seen[p.Name] = true
+		if p.Table != "" && p.Table != t.Name {
+			return fmt.Errorf("table %q, policy %q: declared for table %q", t.Name, p.Name, p.Table)
+		}
+		if err := p.validateExpressions(); err != nil {
+			return fmt.Errorf("table %q, policy %q: %w", t.Name, p.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateExpressions enforces PostgreSQL's rules on which commands take
+// USING and WITH CHECK expressions.
+func (p *Policy) validateExpressions() error {
+	switch p.Command {
+	case "", PolicyAll, PolicyUpdate:
+	case PolicySelect, PolicyDelete:
+		if p.WithCheck != "" {
+			return fmt.Errorf("%s policies cannot have a with_check expression", p.Command)
+		}
+	case PolicyInsert:
+		if p.Using != "" {
+			return errors.New("INSERT policies cannot have a using expression")
+		}
+	default:
+		return fmt.Errorf("invalid command %q", p.Command)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePolicies(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectPostgreSQL),
+		Tables: []*Table{{
+			Name: "documents",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "owner_id", Type: DataTypeInt},
+			},
+			RowLevelSecurity: true,
+			Policies: []*Policy{
+				{Name: "documents_owner", Table: "documents", Command: PolicyAll, Roles: []string{"app_user"},
+					Using: "owner_id = current_user_id()", WithCheck: "owner_id = current_user_id()", Permissive: true},
+				{Name: "documents_insert", Command: PolicyInsert, WithCheck: "owner_id IS NOT NULL"},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint())
+	assert.NotNil(t, db.Tables[0].FindPolicy("documents_insert"))
+}
+
+func TestValidatePolicyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []*Policy
+		want     string
+	}{
+		{
+			name:     "duplicate name",
+			policies: []*Policy{{Name: "p_read", Using: "true"}, {Name: "p_read", Using: "true"}},
+			want:     `table "documents": duplicate policy name "p_read"`,
+		},
+		{
+			name:     "not snake case",
+			policies: []*Policy{{Name: "PRead", Using: "true"}},
+			want:     "must be in snake_case",
+		},
+		{
+			name:     "other table",
+			policies: []*Policy{{Name: "p_read", Table: "users", Using: "true"}},
+			want:     `declared for table "users"`,
+		},
+		{
+			name:     "invalid command",
+			policies: []*Policy{{Name: "p_read", Command: "MERGE", Using: "true"}},
+			want:     `invalid command "MERGE"`,
+		},
+		{
+			name:     "with check on select",
+			policies: []*Policy{{Name: "p_read", Command: PolicySelect, WithCheck: "true"}},
+			want:     "SELECT policies cannot have a with_check expression",
+		},
+		{
+			name:     "using on insert",
+			policies: []*Policy{{Name: "p_add", Command: PolicyInsert, Using: "true"}},
+			want:     "INSERT policies cannot have a using expression",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectPostgreSQL),
+				Tables: []*Table{{
+					Name: "documents",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt},
+						{Name: "owner_id", Type: DataTypeInt},
+					},
+					RowLevelSecurity: true,
+					Policies:         tt.policies,
+				}},
+			}
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintRowLevelSecurityUnsupportedByDialect(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMySQL),
+		Tables: []*Table{{
+			Name: "documents",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "owner_id", Type: DataTypeInt},
+			},
+			RowLevelSecurity: true,
+			Policies:         []*Policy{{Name: "documents_owner", Using: "owner_id = 1"}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "tables[0].row_level_security", warnings[0].Path)
+	assert.Equal(t, `table "documents": row-level security is only supported by postgresql, not mysql`, warnings[0].Message)
+	assert.Equal(t, "tables[0].policies[0]", warnings[1].Path)
+	assert.Equal(t, WarningDialectUnsupported, warnings[1].Code)
+
+	assert.Empty(t, db.Lint(DialectPostgreSQL))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 func (db *Database) validateTableUniqueness() error {
@@ -29,27 +30,40 @@ func (db *Database) validateAndSynthesizeConstraints() error {
 
 func (db *Database) validateTableStructures(nameRe *regexp.Regexp) error {
 	for _, table := range db.Tables {
-		if err := table.Validate(db.Validation, nameRe); err != nil {
+		if err := table.Validate(*db.Dialect, db.Validation, nameRe); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// Validate checks a single table for structural correctness.
-func (t *Table) Validate(rules *ValidationRules, nameRe *regexp.Regexp) error {
+// Validate checks a single table of a schema for dialect d for structural
+// correctness.
+func (t *Table) Validate(d Dialect, rules *ValidationRules, nameRe *regexp.Regexp) error {
 	if err := t.validateNameAndOptions(rules, nameRe); err != nil {
 		return err
 	}
 	if err := t.validateColumns(rules, nameRe); err != nil {
 		return err
 	}
-	if err := t.validateConstraints(); err != nil {
+	if err := t.validateStatsColumns(); err != nil {
+		return err
+	}
+	if err := t.validateConstraints(d); err != nil {
+		return err
+	}
+	if err := t.validateSystemVersioning(); err != nil {
+		return err
+	}
+	if err := t.validateGeneratedColumns(d); err != nil {
 		return err
 	}
 	if err := t.validateTimestamps(); err != nil {
 		return err
 	}
+	if err := t.validatePolicies(); err != nil {
+		return err
+	}
 	return t.validateIndexes()
 }
 
@@ -60,6 +74,25 @@ func (t *Table) validateNameAndOptions(rules *ValidationRules, nameRe *regexp.Re
 	if err := t.Options.Validate(); err != nil {
 		return fmt.Errorf("table %q: %w", t.Name, err)
 	}
+	if err := t.validateDialectLimits(); err != nil {
+		return fmt.Errorf("table %q: %w", t.Name, err)
+	}
+	return nil
+}
+
+// validateStatsColumns checks that the TiDB STATS_COL_LIST names columns of
+// the table.
+func (t *Table) validateStatsColumns() error {
+	o := t.Options.TiDB
+	if o == nil || o.StatsColList == "" {
+		return nil
+	}
+	for name := range strings.SplitSeq(o.StatsColList, ",") {
+		name = strings.TrimSpace(name)
+		if t.FindColumn(name) == nil {
+			return fmt.Errorf("table %q: tidb stats_col_list references nonexistent column %q", t.Name, name)
+		}
+	}
 	return nil
 }
 
@@ -83,10 +116,97 @@ func (t *Table) validateColumns(rules *ValidationRules, nameRe *regexp.Regexp) e
 }
 
 func (opt *TableOptions) Validate() error {
-	// Dialect-specific validations can be added here as needed.
+	if opt.TiDB != nil {
+		if err := opt.TiDB.validateStats(); err != nil {
+			return fmt.Errorf("tidb: %w", err)
+		}
+	}
 	return nil
 }
 
+// validateStats checks the STATS_* table options. A zero sample rate means
+// the option is unset.
+func (o *TiDBTableOptions) validateStats() error {
+	if o.StatsSampleRate < 0 || o.StatsSampleRate > 1 {
+		return fmt.Errorf("stats_sample_rate %v must be in (0, 1]", o.StatsSampleRate)
+	}
+	switch strings.ToUpper(o.StatsColsChoice) {
+	case "", "DEFAULT", "ALL":
+		if o.StatsColList != "" {
+			return errors.New(`stats_col_list requires stats_cols_choice = "LIST"`)
+		}
+	case "LIST":
+		if strings.TrimSpace(o.StatsColList) == "" {
+			return errors.New(`stats_cols_choice = "LIST" requires stats_col_list`)
+		}
+	default:
+		return fmt.Errorf("invalid stats_cols_choice %q: expected DEFAULT, ALL or LIST", o.StatsColsChoice)
+	}
+	return nil
+}
+
+// validateGeneratedColumns checks that generation expressions only read
+// columns of the table and that generated columns do not depend on
+// themselves, directly or through other generated columns.
+func (t *Table) validateGeneratedColumns(d Dialect) error {
+	for _, c := range t.Columns {
+		if !c.IsGenerated {
+			continue
+		}
+		for _, name := range ExpressionColumns(c.GenerationExpression, d) {
+			if t.FindColumn(name) == nil {
+				return fmt.Errorf("table %q: column %q: generation expression references nonexistent column %q", t.Name, c.Name, name)
+			}
+		}
+	}
+	if _, err := t.GeneratedColumnOrder(d); err != nil {
+		return fmt.Errorf("table %q: %w", t.Name, err)
+	}
+	return nil
+}
+
+// GeneratedColumnOrder returns the generated columns of the table ordered so
+// that every column comes after the generated columns its expression reads.
+// Columns without dependencies keep their declaration order. Expressions are
+// read as written for d.
+func (t *Table) GeneratedColumnOrder(d Dialect) ([]*Column, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var order []*Column
+	var visit func(c *Column, path []string) error
+	visit = func(c *Column, path []string) error {
+		switch state[c.Name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("generated column %q depends on itself: %s", c.Name, strings.Join(append(path, c.Name), " -> "))
+		}
+		state[c.Name] = visiting
+		for _, name := range ExpressionColumns(c.GenerationExpression, d) {
+			if dep := t.FindColumn(name); dep != nil && dep.IsGenerated {
+				if err := visit(dep, append(path, c.Name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[c.Name] = done
+		order = append(order, c)
+		return nil
+	}
+	for _, c := range t.Columns {
+		if !c.IsGenerated {
+			continue
+		}
+		if err := visit(c, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
 // validatePKConflict ensures a table doesn't define primary keys both at the
 // column level (primary_key = true) and in the constraints section.
 func (t *Table) validatePrimaryKeyConflict() error {
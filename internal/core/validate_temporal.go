@@ -0,0 +1,47 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// validateSystemVersioning checks the PERIOD FOR SYSTEM_TIME columns of a
+// SQL Server system-versioned table. MariaDB declares its row start and end
+// columns implicitly, so WITH SYSTEM VERSIONING needs no columns.
+func (t *Table) validateSystemVersioning() error {
+	o := t.Options.SQLServer
+	if o == nil {
+		return nil
+	}
+	if !o.SystemVersioning {
+		if o.PeriodStartColumn != "" || o.PeriodEndColumn != "" || o.HistoryTable != "" {
+			return fmt.Errorf("table %q: period columns and history_table require system_versioning", t.Name)
+		}
+		return nil
+	}
+	if o.PeriodStartColumn == "" || o.PeriodEndColumn == "" {
+		return fmt.Errorf("table %q: system_versioning requires period_start_column and period_end_column", t.Name)
+	}
+	if o.PeriodStartColumn == o.PeriodEndColumn {
+		return fmt.Errorf("table %q: period_start_column and period_end_column must differ", t.Name)
+	}
+	for _, name := range []string{o.PeriodStartColumn, o.PeriodEndColumn} {
+		if err := t.validatePeriodColumn(name); err != nil {
+			return fmt.Errorf("table %q, column %q: %w", t.Name, name, err)
+		}
+	}
+	return nil
+}
+
+func (t *Table) validatePeriodColumn(name string) error {
+	c := t.FindColumn(name)
+	switch {
+	case c == nil:
+		return errors.New("period column does not exist")
+	case c.Type != DataTypeDatetime:
+		return fmt.Errorf("period column must be a datetime, not %s", c.Type)
+	case c.Nullable:
+		return errors.New("period column must not be nullable")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSystemVersioning(t *testing.T) {
+	db := &Database{
+		Name:    "app",
+		Dialect: new(DialectMSSQL),
+		Tables: []*Table{{
+			Name: "prices",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "valid_from", Type: DataTypeDatetime},
+				{Name: "valid_to", Type: DataTypeDatetime},
+				{Name: "note", Type: DataTypeString, Nullable: true},
+				{Name: "archived_at", Type: DataTypeDatetime, Nullable: true},
+			},
+			Options: TableOptions{SQLServer: &SQLServerTableOptions{
+				SystemVersioning:  true,
+				PeriodStartColumn: "valid_from",
+				PeriodEndColumn:   "valid_to",
+				HistoryTable:      "dbo.prices_history",
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	mariadb := &Database{
+		Name:    "app",
+		Dialect: new(DialectMariaDB),
+		Tables: []*Table{{
+			Name:    "prices",
+			Columns: []*Column{{Name: "id", Type: DataTypeInt}},
+			Options: TableOptions{MariaDB: &MariaDBTableOptions{WithSystemVersioning: true}},
+		}},
+	}
+	require.NoError(t, mariadb.Validate(), "MariaDB period columns are implicit")
+}
+
+func TestValidateSystemVersioningErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *SQLServerTableOptions
+		want string
+	}{
+		{
+			name: "missing period columns",
+			opts: &SQLServerTableOptions{SystemVersioning: true, PeriodStartColumn: "valid_from"},
+			want: "system_versioning requires period_start_column and period_end_column",
+		},
+		{
+			name: "period without versioning",
+			opts: &SQLServerTableOptions{HistoryTable: "prices_history"},
+			want: "period columns and history_table require system_versioning",
+		},
+		{
+			name: "same column twice",
+			opts: &SQLServerTableOptions{SystemVersioning: true, PeriodStartColumn: "valid_from", PeriodEndColumn: "valid_from"},
+			want: "must differ",
+		},
+		{
+			name: "unknown column",
+			opts: &SQLServerTableOptions{SystemVersioning: true, PeriodStartColumn: "valid_from", PeriodEndColumn: "valid_until"},
+			want: `column "valid_until": period column does not exist`,
+		},
+		{
+			name: "not a datetime",
+			opts: &SQLServerTableOptions{SystemVersioning: true, PeriodStartColumn: "note", PeriodEndColumn: "valid_to"},
+			want: "period column must be a datetime, not string",
+		},
+		{
+			name: "nullable",
+			opts: &SQLServerTableOptions{SystemVersioning: true, PeriodStartColumn: "valid_from", PeriodEndColumn: "archived_at"},
+			want: "period column must not be nullable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:    "app",
+				Dialect: new(DialectMSSQL),
+				Tables: []*Table{{
+					Name: "prices",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt},
+						{Name: "valid_from", Type: DataTypeDatetime},
+						{Name: "valid_to", Type: DataTypeDatetime},
+						{Name: "note", Type: DataTypeString, Nullable: true},
+						{Name: "archived_at", Type: DataTypeDatetime, Nullable: true},
+					},
+					Options: TableOptions{SQLServer: tt.opts},
+				}},
+			}
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
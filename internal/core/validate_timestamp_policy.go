@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TimestampPolicy restricts which kind of date-time type columns may use.
+type TimestampPolicy string
+
+const (
+	// TimestampPolicyAny allows both kinds; it is the default.
+	TimestampPolicyAny TimestampPolicy = "any"
+	// TimestampPolicyTimestampOnly requires time-zone aware types, whose
+	// values are converted to and from UTC (MySQL TIMESTAMP, PostgreSQL
+	// timestamptz).
+	TimestampPolicyTimestampOnly TimestampPolicy = "timestamp_only"
+	// TimestampPolicyDatetimeOnly requires naive types, whose values are
+	// stored as written (MySQL DATETIME, PostgreSQL timestamp).
+	TimestampPolicyDatetimeOnly TimestampPolicy = "datetime_only"
+)
+
+var timestampPolicies = []TimestampPolicy{TimestampPolicyAny, TimestampPolicyTimestampOnly, TimestampPolicyDatetimeOnly}
+
+// zonedTypes and naiveTypes list the time-zone aware and naive date-time base
+// types of each dialect. The first entry of each list is suggested in errors.
+// SQLite has no date-time types and is not listed.
+var (
+	zonedTypes = map[Dialect][]string{
+		DialectMySQL:      {"TIMESTAMP"},
+		DialectMariaDB:    {"TIMESTAMP"},
+		DialectTiDB:       {"TIMESTAMP"},
+		DialectPostgreSQL: {"TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE"},
+		DialectOracle:     {"TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE"},
+		DialectSnowflake:  {"TIMESTAMP_TZ", "TIMESTAMP_LTZ"},
+		DialectMSSQL:      {"DATETIMEOFFSET"},
+	}
+	naiveTypes = map[Dialect][]string{
+		DialectMySQL:      {"DATETIME"},
+		DialectMariaDB:    {"DATETIME"},
+		DialectTiDB:       {"DATETIME"},
+		DialectPostgreSQL: {"TIMESTAMP", "TIMESTAMP WITHOUT TIME ZONE"},
+		DialectOracle:     {"TIMESTAMP"},
+		// TIMESTAMP maps to TIMESTAMP_NTZ unless TIMESTAMP_TYPE_MAPPING is changed.
+		DialectSnowflake: {"TIMESTAMP_NTZ", "DATETIME", "TIMESTAMP"},
+		DialectMSSQL:     {"DATETIME2", "DATETIME", "SMALLDATETIME"},
+		DialectDB2:       {"TIMESTAMP"},
+	}
+)
+
+// validateTimestampPolicy checks the date-time columns against [validation]
+// timestamp_policy. Only columns with a raw type are checked here; parsers
+// check the portable type names with CheckTimestampType, as Type does not
+// tell datetime from timestamp.
+func (db *Database) validateTimestampPolicy() error {
+	if db.Validation == nil || db.Validation.TimestampPolicy == "" {
+		return nil
+	}
+	policy := db.Validation.TimestampPolicy
+	if !slices.Contains(timestampPolicies, policy) {
+		return fmt.Errorf("invalid timestamp_policy %q; expected one of %v", policy, timestampPolicies)
+	}
+	for _, t := range db.Tables {
+		for _, c := range t.Columns {
+			if c.RawType == "" {
+				continue
+			}
+			if err := db.CheckTimestampType(c.RawType); err != nil {
+				return fmt.Errorf("table %q, column %q: %w", t.Name, c.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckTimestampType reports an error when typ, a raw or portable type name
+// read as written in the dialect of db, violates [validation]
+// timestamp_policy. Unknown policies are left to Validate.
+func (db *Database) CheckTimestampType(typ string) error {
+	if db.Validation == nil {
+		return nil
+	}
+	policy := db.Validation.TimestampPolicy
+	if policy != TimestampPolicyTimestampOnly && policy != TimestampPolicyDatetimeOnly {
+		return nil
+	}
+	dialect := *db.Dialect
+	allowed, banned := zonedTypes[dialect], naiveTypes[dialect]
+	kind := "time-zone aware"
+	if policy == TimestampPolicyDatetimeOnly {
+		allowed, banned = banned, allowed
+		kind = "naive"
+	}
+	if !slices.Contains(banned, normalizeRawTypeBase(typ)) {
+		return nil
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("%s violates timestamp_policy %q; %s has no %s date-time type", typ, policy, dialect, kind)
+	}
+	return fmt.Errorf("%s violates timestamp_policy %q; use a %s type such as %s", typ, policy, kind, allowed[0])
+}
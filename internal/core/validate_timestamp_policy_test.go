@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTimestampPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		policy  TimestampPolicy
+		rawType string
+		want    string
+	}{
+		{"mysql timestamp only", DialectMySQL, TimestampPolicyTimestampOnly, "TIMESTAMP(6)", ""},
+		{"mysql datetime under timestamp only", DialectMySQL, TimestampPolicyTimestampOnly, "datetime", `table "events", column "starts_at": datetime violates timestamp_policy "timestamp_only"; use a time-zone aware type such as TIMESTAMP`},
+		{"mysql timestamp under datetime only", DialectMySQL, TimestampPolicyDatetimeOnly, "TIMESTAMP", `TIMESTAMP violates timestamp_policy "datetime_only"; use a naive type such as DATETIME`},
+		{"postgresql timestamp is naive", DialectPostgreSQL, TimestampPolicyTimestampOnly, "timestamp(3) without time zone", `use a time-zone aware type such as TIMESTAMPTZ`},
+		{"postgresql timestamptz", DialectPostgreSQL, TimestampPolicyTimestampOnly, "timestamptz", ""},
+		{"mssql datetimeoffset under datetime only", DialectMSSQL, TimestampPolicyDatetimeOnly, "DATETIMEOFFSET(7)", `use a naive type such as DATETIME2`},
+		{"db2 has no zoned type", DialectDB2, TimestampPolicyTimestampOnly, "TIMESTAMP", `TIMESTAMP violates timestamp_policy "timestamp_only"; db2 has no time-zone aware date-time type`},
+		{"any", DialectMySQL, TimestampPolicyAny, "DATETIME", ""},
+		{"portable types are checked by the parser", DialectMySQL, TimestampPolicyTimestampOnly, "", ""},
+		{"unknown policy", DialectMySQL, "utc", "TIMESTAMP", `invalid timestamp_policy "utc"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:       "app",
+				Dialect:    new(tt.dialect),
+				Validation: &ValidationRules{TimestampPolicy: tt.policy},
+				Tables: []*Table{{
+					Name: "events",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt, PrimaryKey: true},
+						{Name: "starts_at", Type: DataTypeDatetime, RawType: tt.rawType},
+					},
+				}},
+			}
+			err := db.Validate()
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
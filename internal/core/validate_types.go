@@ -0,0 +1,95 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// validateUserTypes checks the database-level enum types and domains and
+// resolves the columns declared with them. Columns get the portable type,
+// the raw base type and the enum values of their user type, so later
+// validation and dialects without named types see an ordinary column.
+func (db *Database) validateUserTypes() error {
+	seen := make(map[string]bool, len(db.EnumTypes)+len(db.Domains))
+	for _, e := range db.EnumTypes {
+		if err := validateName(e.Name, nil, nil, false); err != nil {
+			return fmt.Errorf("enum type %q: %w", e.Name, err)
+		}
+		if seen[e.Name] {
+			return fmt.Errorf("duplicate type name %q", e.Name)
+		}
+		seen[e.Name] = true
+		if len(e.Values) == 0 {
+			return fmt.Errorf("enum type %q has no values", e.Name)
+		}
+		for i, v := range e.Values {
+			if slices.Contains(e.Values[:i], v) {
+				return fmt.Errorf("enum type %q: duplicate value %q", e.Name, v)
+			}
+		}
+	}
+	for _, d := range db.Domains {
+		if err := validateName(d.Name, nil, nil, false); err != nil {
+			return fmt.Errorf("domain %q: %w", d.Name, err)
+		}
+		if seen[d.Name] {
+			return fmt.Errorf("duplicate type name %q", d.Name)
+		}
+		seen[d.Name] = true
+		if strings.TrimSpace(d.BaseType) == "" {
+			return fmt.Errorf("domain %q: base type is empty", d.Name)
+		}
+	}
+
+	for _, t := range db.Tables {
+		for _, c := range t.Columns {
+			if err := db.resolveUserType(c); err != nil {
+				return fmt.Errorf("table %q, column %q: %w", t.Name, c.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (db *Database) resolveUserType(c *Column) error {
+	if c.UserType == "" {
+		return nil
+	}
+	if e := db.FindEnumType(c.UserType); e != nil {
+		if len(c.EnumValues) > 0 && !slices.Equal(c.EnumValues, e.Values) {
+			return fmt.Errorf("values conflict with enum type %q", e.Name)
+		}
+		c.Type = DataTypeEnum
+		c.EnumValues = slices.Clone(e.Values)
+		return nil
+	}
+	if d := db.FindDomain(c.UserType); d != nil {
+		c.Type = NormalizeDataType(d.BaseType)
+		if c.RawType == "" {
+			c.RawType = d.BaseType
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown type %q", c.UserType)
+}
+
+// FindEnumType looks for a database-level enum type by name.
+func (db *Database) FindEnumType(name string) *EnumType {
+	for _, e := range db.EnumTypes {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// FindDomain looks for a database-level domain by name.
+func (db *Database) FindDomain(name string) *Domain {
+	for _, d := range db.Domains {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateResolvesUserTypes(t *testing.T) {
+	db := &Database{
+		Name:      "app",
+		Dialect:   new(DialectPostgreSQL),
+		EnumTypes: []*EnumType{{Name: "mood", Values: []string{"sad", "ok", "happy"}}},
+		Domains:   []*Domain{{Name: "email", BaseType: "varchar(320)", Check: "VALUE LIKE '%@%'"}},
+		Tables: []*Table{{
+			Name: "people",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "current_mood", UserType: "mood"},
+				{Name: "contact", UserType: "email"},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+	assert.Empty(t, db.Lint())
+
+	mood := db.Tables[0].FindColumn("current_mood")
+	assert.Equal(t, DataTypeEnum, mood.Type)
+	assert.Equal(t, []string{"sad", "ok", "happy"}, mood.EnumValues)
+
+	contact := db.Tables[0].FindColumn("contact")
+	assert.Equal(t, DataTypeString, contact.Type)
+	assert.Equal(t, "varchar(320)", contact.RawType)
+}
+
+func TestValidateUserTypeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(db *Database)
+		want   string
+	}{
+		{
+			name:   "unknown type",
+			mutate: func(db *Database) { db.Tables[0].Columns[1].UserType = "colour" },
+			want:   `table "people", column "current_mood": unknown type "colour"`,
+		},
+		{
+			name:   "duplicate name",
+			mutate: func(db *Database) { db.Domains[0].Name = "mood" },
+			want:   `duplicate type name "mood"`,
+		},
+		{
+			name:   "enum without values",
+			mutate: func(db *Database) { db.EnumTypes[0].Values = nil },
+			want:   `enum type "mood" has no values`,
+		},
+		{
+			name:   "duplicate enum value",
+			mutate: func(db *Database) { db.EnumTypes[0].Values = []string{"ok", "ok"} },
+			want:   `enum type "mood": duplicate value "ok"`,
+		},
+		{
+			name:   "domain without base type",
+			mutate: func(db *Database) { db.Domains[0].BaseType = "" },
+			want:   `domain "email": base type is empty`,
+		},
+		{
+			name:   "conflicting column values",
+			mutate: func(db *Database) { db.Tables[0].Columns[1].EnumValues = []string{"meh"} },
+			want:   `values conflict with enum type "mood"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{
+				Name:      "app",
+				Dialect:   new(DialectPostgreSQL),
+				EnumTypes: []*EnumType{{Name: "mood", Values: []string{"sad", "ok", "happy"}}},
+				Domains:   []*Domain{{Name: "email", BaseType: "varchar(320)", Check: "VALUE LIKE '%@%'"}},
+				Tables: []*Table{{
+					Name: "people",
+					Columns: []*Column{
+						{Name: "id", Type: DataTypeInt},
+						{Name: "current_mood", UserType: "mood"},
+					},
+				}},
+			}
+			tt.mutate(db)
+			err := db.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLintDomainsUnsupportedByDialect(t *testing.T) {
+	db := &Database{
+		Name:      "app",
+		Dialect:   new(DialectMySQL),
+		EnumTypes: []*EnumType{{Name: "mood", Values: []string{"sad", "ok", "happy"}}},
+		Domains:   []*Domain{{Name: "email", BaseType: "varchar(320)", Check: "VALUE LIKE '%@%'"}},
+		Tables: []*Table{{
+			Name: "people",
+			Columns: []*Column{
+				{Name: "id", Type: DataTypeInt},
+				{Name: "contact", UserType: "email"},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	warnings := db.Lint()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningDialectUnsupported, warnings[0].Code)
+	assert.Equal(t, "domains[0]", warnings[0].Path)
+	assert.Equal(t, `domain "email": domains are only supported by postgresql; mysql columns use the base type "varchar(320)" without its constraints`, warnings[0].Message)
+}
@@ -0,0 +1,79 @@
+package core
+
+// WarningCode is a stable identifier for a kind of non-fatal schema finding.
+// Codes never change once released so that tooling can match on them.
+type WarningCode string
+
+const (
+	// WarningUnknownKey flags a key in the schema source that smf does not recognize.
+	WarningUnknownKey WarningCode = "unknown-key"
+	// WarningStrayDialectOptions flags a dialect-specific option group that no target dialect reads.
+	WarningStrayDialectOptions WarningCode = "stray-dialect-options"
+	// WarningDeprecatedSyntax flags a construct that still parses but has a
+	// newer spelling; `smf upgrade-schema` rewrites it.
+	WarningDeprecatedSyntax WarningCode = "deprecated-syntax"
+	// WarningUnsupportedFeature flags a construct of an imported foreign
+	// schema format (such as Prisma) that has no smf equivalent and was skipped.
+	WarningUnsupportedFeature WarningCode = "unsupported-feature"
+	// WarningDialectUnsupported flags a schema feature none of the target
+	// dialects can express; generators have to drop or emulate it.
+	WarningDialectUnsupported WarningCode = "dialect-unsupported"
+	// WarningMissingExtension flags a type or function that needs a
+	// PostgreSQL extension the schema does not declare.
+	WarningMissingExtension WarningCode = "missing-extension"
+	// WarningExcludedReference flags a foreign key whose referenced table is
+	// omitted for a target dialect the referencing table exists for.
+	WarningExcludedReference WarningCode = "excluded-reference"
+	// WarningRowSizeLimit flags a table whose estimated MySQL row size
+	// exceeds the server or InnoDB limit.
+	WarningRowSizeLimit WarningCode = "row-size-limit"
+	// WarningUnindexedForeignKey flags a foreign key whose columns no index
+	// covers for a target dialect that does not index them automatically.
+	WarningUnindexedForeignKey WarningCode = "unindexed-foreign-key"
+	// WarningNamingConvention flags a constraint or index name that does not
+	// match the [validation.naming] pattern for its kind.
+	WarningNamingConvention WarningCode = "naming-convention"
+	// WarningMissingPrimaryKey flags a table without a primary key when
+	// [validation] require_primary_key is set.
+	WarningMissingPrimaryKey WarningCode = "missing-primary-key"
+	// WarningImplicitCollation flags a column that sets only one of charset
+	// and collate and silently gets a character set or collation other than
+	// the table default.
+	WarningImplicitCollation WarningCode = "implicit-collation"
+	// WarningUnknownType flags a column or type alias whose type smf does
+	// not know; it is passed to the declared dialect as written.
+	WarningUnknownType WarningCode = "unknown-type"
+)
+
+// Warning is a non-fatal finding about a schema. Unlike validation errors,
+// warnings do not stop parsing unless the schema opts into a strict mode.
+type Warning struct {
+	// Code is the stable identifier of the finding.
+	Code WarningCode `json:"code"`
+	// Table is the table the finding belongs to (empty for database-level findings).
+	Table string `json:"table,omitempty"`
+	// Object is the column, constraint, index or key the finding is about.
+	Object string `json:"object,omitempty"`
+	// Path is the location of the finding in the source document (e.g. the
+	// TOML key path "tables[0].columns[2].nullabe"), when known.
+	Path string `json:"path,omitempty"`
+	// Line is the 1-based line of the finding in the source document, when known.
+	Line int `json:"line,omitempty"`
+	// Message is the human-readable description.
+	Message string `json:"message"`
+}
+
+// String returns the warning formatted for display.
+func (w Warning) String() string {
+	return string(w.Code) + ": " + w.Message
+}
+
+// WarningError is a warning promoted to an error by a strict validation rule
+// (e.g. strict_keys or strict_dialect_options).
+type WarningError struct {
+	Warning Warning
+}
+
+func (e *WarningError) Error() string {
+	return e.Warning.Message
+}
@@ -0,0 +1,122 @@
+// Package docs renders human-readable schema documentation from a
+// core.Database: one page per table plus an index page that groups tables by
+// foreign-key cluster. Output is deterministic so it can be committed.
+package docs
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"smf/internal/core"
+)
+
+// Format selects the output format of Generate.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// Formats returns the supported output formats.
+func Formats() []Format {
+	return []Format{FormatMarkdown, FormatHTML}
+}
+
+// Page is a single generated file.
+type Page struct {
+	// Name is the file name relative to the output directory.
+	Name string
+	// Content is the rendered page.
+	Content []byte
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+// renderer executes a named template from either template package.
+type renderer interface {
+	ExecuteTemplate(w *bytes.Buffer, name string, data any) error
+}
+
+type textRenderer struct{ t *texttemplate.Template }
+
+func (r textRenderer) ExecuteTemplate(w *bytes.Buffer, name string, data any) error {
+	return r.t.ExecuteTemplate(w, name, data)
+}
+
+type htmlRenderer struct{ t *htmltemplate.Template }
+
+func (r htmlRenderer) ExecuteTemplate(w *bytes.Buffer, name string, data any) error {
+	return r.t.ExecuteTemplate(w, name, data)
+}
+
+func newRenderer(format Format) (renderer, string, error) {
+	switch format {
+	case FormatMarkdown:
+		t, err := texttemplate.New("").Funcs(texttemplate.FuncMap{"cell": markdownCell, "inc": inc}).
+			ParseFS(templateFS, "templates/*.md.tmpl")
+		if err != nil {
+			return nil, "", err
+		}
+		return textRenderer{t}, ".md", nil
+	case FormatHTML:
+		t, err := htmltemplate.New("").Funcs(htmltemplate.FuncMap{"inc": inc}).ParseFS(templateFS, "templates/*.html.tmpl")
+		if err != nil {
+			return nil, "", err
+		}
+		return htmlRenderer{t}, ".html", nil
+	default:
+		return nil, "", fmt.Errorf("docs: unsupported format %q", format)
+	}
+}
+
+// Generate renders the documentation pages for db. It expects a database
+// that has already passed Validate, so foreign keys declared on columns have
+// been synthesized into constraints. Pages are returned with the index first
+// and tables in name order.
+func Generate(db *core.Database, format Format) ([]Page, error) {
+	r, ext, err := newRenderer(format)
+	if err != nil {
+		return nil, err
+	}
+
+	site := buildSite(db, ext)
+	pages := make([]Page, 0, len(site.Tables)+1)
+
+	var buf bytes.Buffer
+	if err := r.ExecuteTemplate(&buf, "index", site); err != nil {
+		return nil, fmt.Errorf("docs: render index: %w", err)
+	}
+	pages = append(pages, Page{Name: "index" + ext, Content: bytes.Clone(buf.Bytes())})
+
+	for _, page := range site.Tables {
+		buf.Reset()
+		if err := r.ExecuteTemplate(&buf, "table", page); err != nil {
+			return nil, fmt.Errorf("docs: render table %q: %w", page.Name, err)
+		}
+		pages = append(pages, Page{Name: page.File, Content: bytes.Clone(buf.Bytes())})
+	}
+	return pages, nil
+}
+
+// markdownCell escapes a value for use inside a Markdown table cell.
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "<", "&lt;", "\n", " ")
+
+func inc(i int) int { return i + 1 }
+
+// sortedTables returns db.Tables ordered by name without modifying db.
+func sortedTables(db *core.Database) []*core.Table {
+	tables := slices.Clone(db.Tables)
+	slices.SortFunc(tables, func(a, b *core.Table) int { return strings.Compare(a.Name, b.Name) })
+	return tables
+}
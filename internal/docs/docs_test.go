@@ -0,0 +1,151 @@
+package docs
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+	"smf/internal/parser/toml"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+const docsSchema = `
+[database]
+name    = "shop"
+dialect = "mysql"
+
+[[tables]]
+name    = "orders"
+comment = "Customer orders | one row per checkout"
+
+  [tables.timestamps]
+  enabled = true
+
+  [[tables.columns]]
+  name           = "id"
+  type           = "bigint"
+  primary_key    = true
+  auto_increment = true
+
+  [[tables.columns]]
+  name       = "user_id"
+  type       = "bigint"
+  references = "users.id"
+  on_delete  = "CASCADE"
+
+  [[tables.columns]]
+  name    = "status"
+  type    = "enum"
+  values  = ["pending", "paid", "shipped"]
+  default = "pending"
+  comment = "Fulfilment state"
+
+  [[tables.columns]]
+  name  = "total"
+  type  = "decimal(10,2)"
+  check = "total >= 0"
+
+  [[tables.indexes]]
+  name    = "idx_orders_user_status"
+  columns = ["user_id", "status"]
+  comment = "Order history lookups"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+
+  [[tables.columns]]
+  name     = "email"
+  type     = "varchar(255)"
+  unique   = true
+  comment  = "Login <email>"
+
+[[tables]]
+name = "audit_log"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+
+  [[tables.columns]]
+  name     = "message"
+  type     = "text"
+  nullable = true
+`
+
+func goldenDir(format Format) string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..", "test", "data", "docs", string(format))
+}
+
+func parseDocsSchema(t *testing.T) *core.Database {
+	t.Helper()
+	db, err := toml.NewParser().Parse(strings.NewReader(docsSchema))
+	require.NoError(t, err)
+	return db
+}
+
+func TestGenerateGolden(t *testing.T) {
+	for _, format := range Formats() {
+		t.Run(string(format), func(t *testing.T) {
+			pages, err := Generate(parseDocsSchema(t), format)
+			require.NoError(t, err)
+
+			dir := goldenDir(format)
+			if *update {
+				require.NoError(t, os.MkdirAll(dir, 0o755))
+				for _, p := range pages {
+					require.NoError(t, os.WriteFile(filepath.Join(dir, p.Name), p.Content, 0o644))
+				}
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, pages, len(entries), "golden files in %s", dir)
+			for _, p := range pages {
+				want, err := os.ReadFile(filepath.Join(dir, p.Name))
+				require.NoError(t, err, "missing golden file; run go test ./internal/docs -update")
+				assert.Equal(t, string(want), string(p.Content), p.Name)
+			}
+		})
+	}
+}
+
+func TestGeneratePageOrder(t *testing.T) {
+	pages, err := Generate(parseDocsSchema(t), FormatMarkdown)
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range pages {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"index.md", "audit_log.md", "orders.md", "users.md"}, names)
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	db := parseDocsSchema(t)
+	first, err := Generate(db, FormatHTML)
+	require.NoError(t, err)
+	second, err := Generate(db, FormatHTML)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	_, err := Generate(parseDocsSchema(t), "pdf")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported format "pdf"`)
+}
@@ -0,0 +1,259 @@
+package docs
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// Default names of the columns injected by [tables.timestamps], mirroring the
+// TOML parser.
+const (
+	defaultCreatedColumn = "created_at"
+	defaultUpdatedColumn = "updated_at"
+)
+
+type site struct {
+	Name     string
+	Dialect  string
+	Clusters []cluster
+	Tables   []*tablePage
+}
+
+type cluster struct {
+	Tables []link
+}
+
+type link struct {
+	Name string
+	File string
+}
+
+type tablePage struct {
+	Database     string
+	Name         string
+	File         string
+	Comment      string
+	Columns      []columnRow
+	Enums        []enumRow
+	Constraints  []constraintRow
+	ForeignKeys  []foreignKeyRow
+	ReferencedBy []referenceRow
+	Indexes      []indexRow
+}
+
+type columnRow struct {
+	Name      string
+	Type      string
+	Nullable  bool
+	Default   string
+	Key       string
+	Comment   string
+	Timestamp bool
+}
+
+type enumRow struct {
+	Column string
+	Values []string
+}
+
+type constraintRow struct {
+	Name    string
+	Type    string
+	Columns string
+	Check   string
+}
+
+type foreignKeyRow struct {
+	Name       string
+	Columns    string
+	Table      link
+	RefColumns string
+	OnDelete   string
+	OnUpdate   string
+}
+
+type referenceRow struct {
+	Table   link
+	Columns string
+}
+
+type indexRow struct {
+	Name    string
+	Columns string
+	Unique  bool
+	Type    string
+	Comment string
+}
+
+func buildSite(db *core.Database, ext string) *site {
+	s := &site{Name: db.Name}
+	if db.Dialect != nil {
+		s.Dialect = string(*db.Dialect)
+	}
+
+	files := make(map[string]string, len(db.Tables))
+	for _, t := range db.Tables {
+		files[t.Name] = t.Name + ext
+	}
+	linkTo := func(name string) link { return link{Name: name, File: files[name]} }
+
+	for _, members := range core.ForeignKeyClusters(db) {
+		c := cluster{}
+		for _, name := range members {
+			c.Tables = append(c.Tables, linkTo(name))
+		}
+		s.Clusters = append(s.Clusters, c)
+	}
+
+	referencedBy := make(map[string][]referenceRow)
+	for _, t := range sortedTables(db) {
+		for _, c := range t.Constraints {
+			if c.Type == core.ConstraintForeignKey {
+				referencedBy[c.ReferencedTable] = append(referencedBy[c.ReferencedTable],
+					referenceRow{Table: linkTo(t.Name), Columns: strings.Join(c.Columns, ", ")})
+			}
+		}
+	}
+
+	for _, t := range sortedTables(db) {
+		page := buildTablePage(t, linkTo)
+		page.Database = db.Name
+		page.File = files[t.Name]
+		page.ReferencedBy = referencedBy[t.Name]
+		s.Tables = append(s.Tables, page)
+	}
+	return s
+}
+
+func buildTablePage(t *core.Table, linkTo func(string) link) *tablePage {
+	page := &tablePage{Name: t.Name, Comment: t.Comment}
+	timestamps := timestampColumns(t)
+
+	keys := columnKeys(t)
+	for _, c := range t.Columns {
+		row := columnRow{
+			Name:      c.Name,
+			Type:      columnType(c),
+			Nullable:  c.Nullable,
+			Key:       strings.Join(keys[c.Name], ", "),
+			Comment:   c.Comment,
+			Timestamp: slices.Contains(timestamps, c.Name),
+		}
+		if c.DefaultValue != nil {
+			row.Default = *c.DefaultValue
+		}
+		page.Columns = append(page.Columns, row)
+		if len(c.EnumValues) > 0 {
+			page.Enums = append(page.Enums, enumRow{Column: c.Name, Values: c.EnumValues})
+		}
+	}
+
+	for _, c := range t.Constraints {
+		if c.Type == core.ConstraintForeignKey {
+			page.ForeignKeys = append(page.ForeignKeys, foreignKeyRow{
+				Name:       c.Name,
+				Columns:    strings.Join(c.Columns, ", "),
+				Table:      linkTo(c.ReferencedTable),
+				RefColumns: strings.Join(c.ReferencedColumns, ", "),
+				OnDelete:   string(c.OnDelete),
+				OnUpdate:   string(c.OnUpdate),
+			})
+			continue
+		}
+		typ := string(c.Type)
+		if c.NullsNotDistinct {
+			typ += " NULLS NOT DISTINCT"
+		}
+		if c.UsingMethod != "" {
+			typ += " USING " + c.UsingMethod
+		}
+		cols := append(slices.Clone(c.Columns), c.Expressions...)
+		for _, e := range c.Elements {
+			cols = append(cols, e.String())
+		}
+		page.Constraints = append(page.Constraints, constraintRow{
+			Name:    c.Name,
+			Type:    typ,
+			Columns: strings.Join(cols, ", "),
+			Check:   c.CheckExpression,
+		})
+	}
+
+	for _, idx := range t.Indexes {
+		cols := make([]string, len(idx.Columns))
+		for i, c := range idx.Columns {
+			cols[i] = c.Name
+			if c.Length > 0 {
+				cols[i] += "(" + strconv.Itoa(c.Length) + ")"
+			}
+			if c.Order != "" {
+				cols[i] += " " + string(c.Order)
+			}
+		}
+		page.Indexes = append(page.Indexes, indexRow{
+			Name:    idx.Name,
+			Columns: strings.Join(cols, ", "),
+			Unique:  idx.Unique,
+			Type:    string(idx.Type),
+			Comment: idx.Comment,
+		})
+	}
+	return page
+}
+
+// columnType prefers the declared dialect type over the portable one.
+func columnType(c *core.Column) string {
+	if c.UserType != "" {
+		return c.UserType
+	}
+	if c.RawType != "" {
+		return c.RawType
+	}
+	return string(c.Type)
+}
+
+// columnKeys returns the short key markers (PK, UQ, FK) of every column.
+func columnKeys(t *core.Table) map[string][]string {
+	keys := make(map[string][]string)
+	add := func(col, key string) {
+		if !slices.Contains(keys[col], key) {
+			keys[col] = append(keys[col], key)
+		}
+	}
+	for _, c := range t.Constraints {
+		var key string
+		switch c.Type {
+		case core.ConstraintPrimaryKey:
+			key = "PK"
+		case core.ConstraintUnique:
+			key = "UQ"
+		case core.ConstraintForeignKey:
+			key = "FK"
+		default:
+			continue
+		}
+		for _, col := range c.Columns {
+			add(col, key)
+		}
+	}
+	return keys
+}
+
+// timestampColumns returns the names of the columns managed by
+// [tables.timestamps], or nil when timestamps are disabled.
+func timestampColumns(t *core.Table) []string {
+	if t.Timestamps == nil || !t.Timestamps.Enabled {
+		return nil
+	}
+	created, updated := defaultCreatedColumn, defaultUpdatedColumn
+	if t.Timestamps.CreatedColumn != "" {
+		created = t.Timestamps.CreatedColumn
+	}
+	if t.Timestamps.UpdatedColumn != "" {
+		updated = t.Timestamps.UpdatedColumn
+	}
+	return []string{created, updated}
+}
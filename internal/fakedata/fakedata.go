@@ -0,0 +1,369 @@
+// Package fakedata generates referentially consistent fake rows for a schema,
+// for loading into test databases. Foreign key columns take their values from
+// the rows generated for the referenced table, unique keys stay distinct and
+// the simple comparison forms of CHECK constraints are respected. The output
+// is deterministic for a given seed.
+package fakedata
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// DefaultRows is the number of rows generated for tables not listed in
+// Options.Rows when Options.DefaultRows is zero.
+const DefaultRows = 10
+
+// maxAttempts bounds how often a row is regenerated to keep its unique keys
+// distinct before giving up.
+const maxAttempts = 100
+
+// nullPercent is the share of NULL values in nullable columns.
+const nullPercent = 10
+
+// Options configures Generate.
+type Options struct {
+	// Rows is the number of rows per table name.
+	Rows map[string]int
+	// DefaultRows is the number of rows for tables not in Rows; zero means
+	// DefaultRows.
+	DefaultRows int
+	// Seed makes the generated values reproducible.
+	Seed uint64
+}
+
+// Table holds the generated rows of a table.
+type Table struct {
+	// Name is the table name.
+	Name string
+	// Columns are the columns the rows have values for. Generated columns
+	// are left out.
+	Columns []string
+	// Rows hold one value per column.
+	Rows [][]Value
+}
+
+// ValueKind tells writers how to render a Value.
+type ValueKind int
+
+const (
+	KindNull ValueKind = iota
+	KindNumber
+	KindBool
+	KindString
+	// KindDate, KindTime and KindDatetime hold "2006-01-02", "15:04:05" and
+	// "2006-01-02 15:04:05" formatted text.
+	KindDate
+	KindTime
+	KindDatetime
+	// KindBinary holds the bytes hex-encoded.
+	KindBinary
+)
+
+// Value is a single generated value.
+type Value struct {
+	Kind ValueKind
+	// Text is the value formatted for its kind; "true" or "false" for
+	// booleans and empty for NULL.
+	Text string
+}
+
+// Null is the NULL value.
+var Null = Value{Kind: KindNull}
+
+// Generate returns the rows for every table of db, ordered so that referenced
+// tables come before the tables referencing them. Foreign keys that form a
+// cycle are broken at a nullable column, which is left NULL in the rows
+// generated before the referenced table.
+func Generate(db *core.Database, opts Options) ([]*Table, error) {
+	for name := range opts.Rows {
+		if db.FindTable(name) == nil {
+			return nil, fmt.Errorf("unknown table %q", name)
+		}
+	}
+	order, err := insertOrder(db)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		db:     db,
+		rng:    rand.New(rand.NewPCG(opts.Seed, opts.Seed^0xfa4eda7a)),
+		tables: make(map[string]*Table),
+	}
+	out := make([]*Table, 0, len(order))
+	for _, t := range order {
+		n, ok := opts.Rows[t.Name]
+		if !ok {
+			n = cmp.Or(opts.DefaultRows, DefaultRows)
+		}
+		data, err := g.table(t, n)
+		if err != nil {
+			return nil, fmt.Errorf("table %q: %w", t.Name, err)
+		}
+		g.tables[t.Name] = data
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// insertOrder sorts the tables so that each comes after the tables its
+// foreign keys reference, keeping schema order otherwise. When the foreign
+// keys form a cycle, the first table whose unmet references are all
+// nullable goes next.
+func insertOrder(db *core.Database) ([]*core.Table, error) {
+	remaining := slices.Clone(db.Tables)
+	var order []*core.Table
+	ready := func(t *core.Table, nullableOK bool) bool {
+		for _, fk := range foreignKeys(t) {
+			if fk.ReferencedTable == t.Name || !slices.ContainsFunc(remaining, func(r *core.Table) bool { return r.Name == fk.ReferencedTable }) {
+				continue
+			}
+			if !nullableOK || !allNullable(t, fk.Columns) {
+				return false
+			}
+		}
+		return true
+	}
+	for len(remaining) > 0 {
+		i := slices.IndexFunc(remaining, func(t *core.Table) bool { return ready(t, false) })
+		if i < 0 {
+			i = slices.IndexFunc(remaining, func(t *core.Table) bool { return ready(t, true) })
+		}
+		if i < 0 {
+			names := make([]string, len(remaining))
+			for j, t := range remaining {
+				names[j] = t.Name
+			}
+			return nil, fmt.Errorf("foreign keys on NOT NULL columns form a cycle between tables %s", strings.Join(names, ", "))
+		}
+		order = append(order, remaining[i])
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+	return order, nil
+}
+
+func foreignKeys(t *core.Table) []*core.Constraint {
+	var fks []*core.Constraint
+	for _, c := range t.Constraints {
+		if c.Type == core.ConstraintForeignKey {
+			fks = append(fks, c)
+		}
+	}
+	return fks
+}
+
+func allNullable(t *core.Table, columns []string) bool {
+	for _, name := range columns {
+		if c := t.FindColumn(name); c == nil || !c.Nullable {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueKeys returns the column lists of the primary key, unique constraints
+// and unique indexes of t.
+func uniqueKeys(t *core.Table) [][]string {
+	var keys [][]string
+	for _, c := range t.Constraints {
+		if (c.Type == core.ConstraintPrimaryKey || c.Type == core.ConstraintUnique) && len(c.Expressions) == 0 {
+			keys = append(keys, c.Columns)
+		}
+	}
+	for _, idx := range t.Indexes {
+		if idx.Unique {
+			keys = append(keys, idx.Names())
+		}
+	}
+	return keys
+}
+
+type generator struct {
+	db     *core.Database
+	rng    *rand.Rand
+	tables map[string]*Table
+}
+
+// tableState is the generation state of the table being filled.
+type tableState struct {
+	t       *core.Table
+	data    *Table
+	columns []*core.Column
+	pos     map[string]int // column name -> index in data.Columns
+	fks     []*core.Constraint
+	keys    [][]string
+	seen    []map[string]bool
+	bounds  map[string]bounds
+	seq     map[string]bool // columns of a unique key, numbered by row
+	fkCols  map[string]bool // columns filled from a referenced table
+	// distinct holds, for foreign keys whose columns alone form a unique
+	// key, the order in which the referenced rows are used, so every row
+	// references a different one.
+	distinct map[*core.Constraint][]int
+}
+
+func (g *generator) table(t *core.Table, n int) (*Table, error) {
+	s := &tableState{
+		t:        t,
+		data:     &Table{Name: t.Name},
+		pos:      make(map[string]int),
+		fks:      foreignKeys(t),
+		keys:     uniqueKeys(t),
+		bounds:   checkBounds(t),
+		seq:      make(map[string]bool),
+		fkCols:   make(map[string]bool),
+		distinct: make(map[*core.Constraint][]int),
+	}
+	for _, fk := range s.fks {
+		for _, c := range fk.Columns {
+			s.fkCols[c] = true
+		}
+		if fk.ReferencedTable == t.Name || !s.uniqueColumns(fk.Columns) {
+			continue
+		}
+		var parent int
+		if ref := g.tables[fk.ReferencedTable]; ref != nil {
+			parent = len(ref.Rows)
+		}
+		if n > parent && !allNullable(t, fk.Columns) {
+			return nil, fmt.Errorf("foreign key %q is unique, so at most %d rows can reference table %q, not %d", fk.Name, parent, fk.ReferencedTable, n)
+		}
+		s.distinct[fk] = g.rng.Perm(parent)
+	}
+	for _, c := range t.Columns {
+		if c.IsGenerated {
+			continue
+		}
+		s.pos[c.Name] = len(s.columns)
+		s.columns = append(s.columns, c)
+		s.data.Columns = append(s.data.Columns, c.Name)
+	}
+	for _, key := range s.keys {
+		for _, name := range key {
+			s.seq[name] = true
+		}
+		s.seen = append(s.seen, make(map[string]bool))
+	}
+
+	for i := range n {
+		row, err := g.row(s, i)
+		if err != nil {
+			return nil, err
+		}
+		s.data.Rows = append(s.data.Rows, row)
+	}
+	return s.data, nil
+}
+
+// row generates row i, retrying until its unique keys are new.
+func (g *generator) row(s *tableState, i int) ([]Value, error) {
+	for range maxAttempts {
+		row := make([]Value, len(s.columns))
+		for j, c := range s.columns {
+			if !s.fkCols[c.Name] {
+				row[j] = g.value(c, i, s.seq[c.Name], s.bounds[c.Name])
+			}
+		}
+		for _, fk := range s.fks {
+			if err := g.reference(s, fk, row, i); err != nil {
+				return nil, err
+			}
+		}
+		if s.claim(row) {
+			return row, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot generate %d rows with distinct unique keys", i+1)
+}
+
+// uniqueColumns reports whether some unique key of the table consists of
+// columns only, so rows must differ in them.
+func (s *tableState) uniqueColumns(columns []string) bool {
+	return slices.ContainsFunc(s.keys, func(key []string) bool {
+		return len(key) > 0 && !slices.ContainsFunc(key, func(c string) bool { return !slices.Contains(columns, c) })
+	})
+}
+
+// claim records the unique keys of row, or reports false when one of them
+// was already used. Keys with a NULL are distinct, as in SQL.
+func (s *tableState) claim(row []Value) bool {
+	keys := make([]string, len(s.keys))
+	for k, key := range s.keys {
+		parts := make([]string, len(key))
+		for j, name := range key {
+			v := row[s.pos[name]]
+			if v.Kind == KindNull {
+				parts = nil
+				break
+			}
+			parts[j] = v.Text
+		}
+		if parts == nil {
+			continue
+		}
+		keys[k] = strings.Join(parts, "\x00")
+		if s.seen[k][keys[k]] {
+			return false
+		}
+	}
+	for k, key := range keys {
+		if key != "" {
+			s.seen[k][key] = true
+		}
+	}
+	return true
+}
+
+// reference fills the columns of a foreign key of row i from a random row of
+// the referenced table. Self-references pick an earlier row or the row
+// itself; unique self-references always pick the row itself. Unique foreign
+// keys use every referenced row at most once and are NULL once they run out.
+func (g *generator) reference(s *tableState, fk *core.Constraint, row []Value, i int) error {
+	parent := g.tables[fk.ReferencedTable]
+	rows := func() [][]Value {
+		if parent == nil {
+			return nil
+		}
+		return parent.Rows
+	}()
+	if fk.ReferencedTable == s.t.Name {
+		parent, rows = s.data, append(slices.Clone(s.data.Rows), row)
+	}
+	nullable := allNullable(s.t, fk.Columns)
+	if len(rows) == 0 || nullable && g.rng.IntN(100) < nullPercent {
+		if !nullable {
+			return fmt.Errorf("foreign key %q references table %q, which has no rows", fk.Name, fk.ReferencedTable)
+		}
+		setNull(s, fk, row)
+		return nil
+	}
+	ref := rows[g.rng.IntN(len(rows))]
+	if perm, ok := s.distinct[fk]; ok {
+		if i >= len(perm) {
+			setNull(s, fk, row)
+			return nil
+		}
+		ref = rows[perm[i]]
+	} else if fk.ReferencedTable == s.t.Name && s.uniqueColumns(fk.Columns) {
+		ref = row
+	}
+	for j, c := range fk.Columns {
+		k := slices.Index(parent.Columns, fk.ReferencedColumns[j])
+		if k < 0 {
+			return fmt.Errorf("foreign key %q: referenced column %q has no generated values", fk.Name, fk.ReferencedColumns[j])
+		}
+		row[s.pos[c]] = ref[k]
+	}
+	return nil
+}
+
+func setNull(s *tableState, fk *core.Constraint, row []Value) {
+	for _, c := range fk.Columns {
+		row[s.pos[c]] = Null
+	}
+}
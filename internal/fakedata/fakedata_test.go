@@ -0,0 +1,248 @@
+package fakedata
+
+import (
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+	"smf/internal/parser/toml"
+)
+
+func shopDB(t *testing.T) *core.Database {
+	t.Helper()
+	_, filename, _, _ := runtime.Caller(0)
+	db, err := toml.NewParser().ParseFile(filepath.Join(filepath.Dir(filename), "..", "..", "test", "data", "gen", "shop.toml"))
+	require.NoError(t, err)
+	return db
+}
+
+func parseDB(t *testing.T, schema string) *core.Database {
+	t.Helper()
+	db, err := toml.NewParser().Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	return db
+}
+
+// column returns the values of a column of a generated table.
+func column(t *testing.T, tables []*Table, table, name string) []Value {
+	t.Helper()
+	i := slices.IndexFunc(tables, func(tb *Table) bool { return tb.Name == table })
+	require.GreaterOrEqual(t, i, 0, "table %s", table)
+	j := slices.Index(tables[i].Columns, name)
+	require.GreaterOrEqual(t, j, 0, "column %s.%s", table, name)
+	values := make([]Value, len(tables[i].Rows))
+	for k, row := range tables[i].Rows {
+		values[k] = row[j]
+	}
+	return values
+}
+
+func texts(values []Value) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v.Kind != KindNull {
+			out = append(out, v.Text)
+		}
+	}
+	return out
+}
+
+func TestGenerateShop(t *testing.T) {
+	db := shopDB(t)
+	tables, err := Generate(db, Options{Rows: map[string]int{"users": 50, "orders": 200, "order_items": 400}, Seed: 1})
+	require.NoError(t, err)
+
+	var order []string
+	for _, tb := range tables {
+		order = append(order, tb.Name)
+	}
+	assert.Equal(t, []string{"users", "orders", "order_items", "categories"}, order, "parents come first")
+	assert.Len(t, column(t, tables, "categories", "id"), DefaultRows)
+	assert.NotContains(t, tables[1].Columns, "total_cents", "generated columns are left out")
+
+	userIDs := texts(column(t, tables, "users", "id"))
+	emails := texts(column(t, tables, "users", "email"))
+	assert.Len(t, emails, 50)
+	assert.Len(t, slices.Compact(slices.Sorted(slices.Values(emails))), 50, "unique columns are distinct")
+	for _, id := range texts(column(t, tables, "orders", "user_id")) {
+		assert.Contains(t, userIDs, id)
+	}
+
+	orderIDs := texts(column(t, tables, "orders", "id"))
+	for _, v := range column(t, tables, "order_items", "order_id") {
+		assert.Contains(t, orderIDs, v.Text)
+	}
+	seen := make(map[string]bool)
+	ids, skus := column(t, tables, "order_items", "order_id"), column(t, tables, "order_items", "sku")
+	for i := range ids {
+		key := ids[i].Text + "/" + skus[i].Text
+		assert.False(t, seen[key], "duplicate primary key %s", key)
+		seen[key] = true
+	}
+
+	for _, v := range column(t, tables, "order_items", "quantity") {
+		n, err := strconv.Atoi(v.Text)
+		require.NoError(t, err)
+		assert.Positive(t, n, "CHECK (quantity > 0)")
+	}
+	for _, v := range column(t, tables, "orders", "status") {
+		assert.Contains(t, []string{"pending", "in-progress", "shipped"}, v.Text)
+	}
+
+	categoryIDs := texts(column(t, tables, "categories", "id"))
+	for _, id := range texts(column(t, tables, "categories", "parent_id")) {
+		assert.Contains(t, categoryIDs, id, "self-references point at generated rows")
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	db := shopDB(t)
+	a, err := Generate(db, Options{Seed: 7})
+	require.NoError(t, err)
+	b, err := Generate(db, Options{Seed: 7})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+	c, err := Generate(db, Options{Seed: 8})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
+
+const cycleSchema = `
+[database]
+name    = "app"
+dialect = "postgresql"
+
+[[tables]]
+name = "teams"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name       = "lead_id"
+  type       = "int"
+  nullable   = true
+  references = "members.id"
+
+[[tables]]
+name = "members"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name       = "team_id"
+  type       = "int"
+  references = "teams.id"
+
+  [[tables.columns]]
+  name  = "score"
+  type  = "int"
+  check = "score BETWEEN 10 AND 20 AND score <> 15"
+
+  [[tables.columns]]
+  name  = "level"
+  type  = "varchar(10)"
+  check = "level IN ('junior', 'senior')"
+`
+
+func TestGenerateCycle(t *testing.T) {
+	db := parseDB(t, cycleSchema)
+	tables, err := Generate(db, Options{DefaultRows: 30})
+	require.NoError(t, err)
+	require.Equal(t, "teams", tables[0].Name, "the nullable reference breaks the cycle")
+	for _, v := range column(t, tables, "teams", "lead_id") {
+		assert.Equal(t, KindNull, v.Kind)
+	}
+	for _, v := range column(t, tables, "members", "score") {
+		n, err := strconv.Atoi(v.Text)
+		require.NoError(t, err)
+		assert.True(t, n >= 10 && n <= 20, "score %d", n)
+	}
+	for _, v := range column(t, tables, "members", "level") {
+		assert.Contains(t, []string{"junior", "senior"}, v.Text)
+	}
+
+	db.FindTable("teams").FindColumn("lead_id").Nullable = false
+	_, err = Generate(db, Options{})
+	require.Error(t, err)
+	assert.Equal(t, "foreign keys on NOT NULL columns form a cycle between tables teams, members", err.Error())
+}
+
+func TestGenerateErrors(t *testing.T) {
+	db := shopDB(t)
+
+	_, err := Generate(db, Options{Rows: map[string]int{"user": 5}})
+	require.Error(t, err)
+	assert.Equal(t, `unknown table "user"`, err.Error())
+
+	_, err = Generate(db, Options{Rows: map[string]int{"users": 0}})
+	require.Error(t, err)
+	assert.Equal(t, `table "orders": foreign key "fk_orders_users" references table "users", which has no rows`, err.Error())
+
+	db.FindTable("orders").FindColumn("status").Unique = true
+	db.FindTable("orders").Constraints = append(db.FindTable("orders").Constraints,
+		&core.Constraint{Name: "uq_orders_status", Type: core.ConstraintUnique, Columns: []string{"status"}})
+	_, err = Generate(db, Options{})
+	require.Error(t, err)
+	assert.Equal(t, `table "orders": cannot generate 4 rows with distinct unique keys`, err.Error())
+}
+
+const oneToOneSchema = `
+[database]
+name = "app"
+dialect = "postgresql"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+[[tables]]
+name = "profiles"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name       = "user_id"
+  type       = "int"
+  unique     = true
+  references = "users.id"
+`
+
+func TestGenerateUniqueForeignKey(t *testing.T) {
+	db := parseDB(t, oneToOneSchema)
+	tables, err := Generate(db, Options{Rows: map[string]int{"users": 1000, "profiles": 1000}, Seed: 1})
+	require.NoError(t, err)
+	ids := texts(column(t, tables, "profiles", "user_id"))
+	require.Len(t, ids, 1000)
+	assert.ElementsMatch(t, texts(column(t, tables, "users", "id")), ids, "every user is referenced exactly once")
+
+	_, err = Generate(db, Options{Rows: map[string]int{"users": 10, "profiles": 11}})
+	require.Error(t, err)
+	assert.Equal(t, `table "profiles": foreign key "fk_profiles_users" is unique, so at most 10 rows can reference table "users", not 11`, err.Error())
+
+	db.FindTable("profiles").FindColumn("user_id").Nullable = true
+	tables, err = Generate(db, Options{Rows: map[string]int{"users": 10, "profiles": 30}})
+	require.NoError(t, err)
+	ids = texts(column(t, tables, "profiles", "user_id"))
+	assert.LessOrEqual(t, len(ids), 10)
+	assert.Len(t, slices.Compact(slices.Sorted(slices.Values(ids))), len(ids))
+}
@@ -0,0 +1,184 @@
+package fakedata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+)
+
+// DefaultBatchSize is the number of rows per INSERT statement when
+// WriteSQL is given no batch size.
+const DefaultBatchSize = 500
+
+// mssqlMaxBatchSize is the most rows SQL Server accepts in one VALUES list.
+const mssqlMaxBatchSize = 1000
+
+// WriteSQL writes the rows as multi-row INSERT statements for the dialect of
+// db, with at most batchSize rows each. SQL Server batches are capped at 1000
+// rows, and Oracle, which has no multi-row VALUES, gets one row per INSERT.
+// The tables are written in the order given, which Generate makes safe for
+// foreign keys. Explicit values are inserted into auto-increment columns so
+// foreign keys can refer to them; the statements needed for that in SQL
+// Server and PostgreSQL are included.
+func WriteSQL(w io.Writer, tables []*Table, db *core.Database, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	dialect := *db.Dialect
+	switch {
+	case dialect == core.DialectOracle:
+		batchSize = 1
+	case dialect == core.DialectMSSQL && batchSize > mssqlMaxBatchSize:
+		batchSize = mssqlMaxBatchSize
+	}
+	bw := bufio.NewWriter(w)
+	first := true
+	for _, t := range tables {
+		if len(t.Rows) == 0 {
+			continue
+		}
+		if !first {
+			bw.WriteString("\n")
+		}
+		first = false
+		table := quoteIdent(t.Name, dialect)
+		columns := make([]string, len(t.Columns))
+		for j, c := range t.Columns {
+			columns[j] = quoteIdent(c, dialect)
+		}
+		identity := autoIncrementColumn(db.FindTable(t.Name), t.Columns)
+		if identity != "" && dialect == core.DialectMSSQL {
+			fmt.Fprintf(bw, "SET IDENTITY_INSERT %s ON;\n", table)
+		}
+		for batch := range slices.Chunk(t.Rows, batchSize) {
+			fmt.Fprintf(bw, "INSERT INTO %s (%s)", table, strings.Join(columns, ", "))
+			if identity != "" && dialect == core.DialectPostgreSQL {
+				bw.WriteString(" OVERRIDING SYSTEM VALUE")
+			}
+			bw.WriteString(" VALUES\n")
+			for j, row := range batch {
+				values := make([]string, len(row))
+				for k, v := range row {
+					values[k] = sqlLiteral(v, dialect)
+				}
+				sep := ",\n"
+				if j == len(batch)-1 {
+					sep = ";\n"
+				}
+				fmt.Fprintf(bw, "  (%s)%s", strings.Join(values, ", "), sep)
+			}
+		}
+		switch {
+		case identity != "" && dialect == core.DialectMSSQL:
+			fmt.Fprintf(bw, "SET IDENTITY_INSERT %s OFF;\n", table)
+		case identity != "" && dialect == core.DialectPostgreSQL:
+			fmt.Fprintf(bw, "SELECT setval(pg_get_serial_sequence('%s', '%s'), (SELECT max(%s) FROM %s));\n",
+				strings.ReplaceAll(table, "'", "''"), strings.ReplaceAll(identity, "'", "''"), quoteIdent(identity, dialect), table)
+		}
+	}
+	return bw.Flush()
+}
+
+// autoIncrementColumn returns the auto-increment column of t among columns.
+func autoIncrementColumn(t *core.Table, columns []string) string {
+	if t == nil {
+		return ""
+	}
+	for _, c := range t.Columns {
+		if c.AutoIncrement && slices.Contains(columns, c.Name) {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+func quoteIdent(name string, d core.Dialect) string {
+	switch d {
+	case core.DialectMySQL, core.DialectMariaDB, core.DialectTiDB:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case core.DialectMSSQL:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+func sqlLiteral(v Value, d core.Dialect) string {
+	quote := func(s string) string {
+		if d == core.DialectMySQL || d == core.DialectMariaDB || d == core.DialectTiDB {
+			// The MySQL family treats a backslash in a string literal as an escape.
+			s = strings.ReplaceAll(s, `\`, `\\`)
+		}
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	switch v.Kind {
+	case KindNull:
+		return "NULL"
+	case KindNumber:
+		return v.Text
+	case KindBool:
+		if d == core.DialectMSSQL || d == core.DialectOracle {
+			if v.Text == "true" {
+				return "1"
+			}
+			return "0"
+		}
+		return strings.ToUpper(v.Text)
+	case KindDate, KindDatetime:
+		if d == core.DialectOracle {
+			if v.Kind == KindDate {
+				return "DATE " + quote(v.Text)
+			}
+			return "TIMESTAMP " + quote(v.Text)
+		}
+		return quote(v.Text)
+	case KindBinary:
+		switch d {
+		case core.DialectPostgreSQL:
+			return `'\x` + v.Text + "'"
+		case core.DialectMSSQL:
+			return "0x" + v.Text
+		case core.DialectOracle:
+			return "HEXTORAW('" + v.Text + "')"
+		default:
+			return "X'" + v.Text + "'"
+		}
+	default:
+		return quote(v.Text)
+	}
+}
+
+// CSVFiles returns one <table>.csv file per table, with a header row of
+// column names. NULL is written as an empty field and binary values as hex.
+func CSVFiles(tables []*Table) ([]gen.File, error) {
+	files := make([]gen.File, 0, len(tables))
+	for _, t := range tables {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(t.Columns); err != nil {
+			return nil, err
+		}
+		record := make([]string, len(t.Columns))
+		for _, row := range t.Rows {
+			for i, v := range row {
+				record[i] = v.Text
+			}
+			if err := w.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		files = append(files, gen.File{Name: t.Name + ".csv", Content: buf.Bytes()})
+	}
+	return files, nil
+}
@@ -0,0 +1,122 @@
+package fakedata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+func outputDB(dialect core.Dialect) (*core.Database, []*Table) {
+	db := &core.Database{
+		Name:    "app",
+		Dialect: &dialect,
+		Tables: []*core.Table{{
+			Name: "events",
+			Columns: []*core.Column{
+				{Name: "id", Type: core.DataTypeInt, PrimaryKey: true, AutoIncrement: true},
+				{Name: "name", Type: core.DataTypeString},
+			},
+		}},
+	}
+	tables := []*Table{
+		{Name: "empty", Columns: []string{"id"}},
+		{
+			Name:    "events",
+			Columns: []string{"id", "name", "active", "at", "payload", "note"},
+			Rows: [][]Value{
+				{{KindNumber, "1"}, {KindString, "it's"}, {KindBool, "true"}, {KindDatetime, "2024-01-01 00:00:00"}, {KindBinary, "00ff"}, Null},
+				{{KindNumber, "2"}, {KindString, "b"}, {KindBool, "false"}, {KindDate, "2024-01-02"}, {KindBinary, "01"}, {KindString, "x"}},
+				{{KindNumber, "3"}, {KindString, "c"}, {KindBool, "true"}, {KindTime, "10:00:00"}, {KindBinary, "02"}, Null},
+			},
+		},
+	}
+	return db, tables
+}
+
+func TestWriteSQL(t *testing.T) {
+	db, tables := outputDB(core.DialectMySQL)
+	var b strings.Builder
+	require.NoError(t, WriteSQL(&b, tables, db, 2))
+	assert.Equal(t, "INSERT INTO `events` (`id`, `name`, `active`, `at`, `payload`, `note`) VALUES\n"+
+		"  (1, 'it''s', TRUE, '2024-01-01 00:00:00', X'00ff', NULL),\n"+
+		"  (2, 'b', FALSE, '2024-01-02', X'01', 'x');\n"+
+		"INSERT INTO `events` (`id`, `name`, `active`, `at`, `payload`, `note`) VALUES\n"+
+		"  (3, 'c', TRUE, '10:00:00', X'02', NULL);\n", b.String())
+}
+
+func TestWriteSQLDialects(t *testing.T) {
+	tests := []struct {
+		dialect core.Dialect
+		want    []string
+	}{
+		{core.DialectPostgreSQL, []string{
+			`INSERT INTO "events" ("id", "name", "active", "at", "payload", "note") OVERRIDING SYSTEM VALUE VALUES`,
+			`(1, 'it''s', TRUE, '2024-01-01 00:00:00', '\x00ff', NULL)`,
+			`SELECT setval(pg_get_serial_sequence('"events"', 'id'), (SELECT max("id") FROM "events"));`,
+		}},
+		{core.DialectMSSQL, []string{
+			"SET IDENTITY_INSERT [events] ON;\nINSERT INTO [events]",
+			"(1, 'it''s', 1, '2024-01-01 00:00:00', 0x00ff, NULL)",
+			"SET IDENTITY_INSERT [events] OFF;\n",
+		}},
+		{core.DialectOracle, []string{
+			"(1, 'it''s', 1, TIMESTAMP '2024-01-01 00:00:00', HEXTORAW('00ff'), NULL)",
+			"DATE '2024-01-02'",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.dialect), func(t *testing.T) {
+			db, tables := outputDB(tt.dialect)
+			var b strings.Builder
+			require.NoError(t, WriteSQL(&b, tables, db, 0))
+			for _, want := range tt.want {
+				assert.Contains(t, b.String(), want)
+			}
+		})
+	}
+}
+
+func TestWriteSQLBatchLimits(t *testing.T) {
+	db, tables := outputDB(core.DialectOracle)
+	var b strings.Builder
+	require.NoError(t, WriteSQL(&b, tables, db, 0))
+	assert.Equal(t, 3, strings.Count(b.String(), "INSERT INTO"))
+	assert.Contains(t, b.String(), "INSERT INTO \"events\" (\"id\", \"name\", \"active\", \"at\", \"payload\", \"note\") VALUES\n"+
+		"  (2, 'b', 0, DATE '2024-01-02', HEXTORAW('01'), 'x');\n")
+
+	db, tables = outputDB(core.DialectMSSQL)
+	row := tables[1].Rows[0]
+	tables[1].Rows = nil
+	for range 1001 {
+		tables[1].Rows = append(tables[1].Rows, row)
+	}
+	b.Reset()
+	require.NoError(t, WriteSQL(&b, tables, db, 5000))
+	assert.Equal(t, 2, strings.Count(b.String(), "INSERT INTO"))
+}
+
+func TestSQLLiteralBackslash(t *testing.T) {
+	v := Value{KindString, `C:\temp\it's`}
+	for _, d := range []core.Dialect{core.DialectMySQL, core.DialectMariaDB, core.DialectTiDB} {
+		assert.Equal(t, `'C:\\temp\\it''s'`, sqlLiteral(v, d), d)
+	}
+	assert.Equal(t, `'C:\temp\it''s'`, sqlLiteral(v, core.DialectPostgreSQL))
+}
+
+func TestCSVFiles(t *testing.T) {
+	_, tables := outputDB(core.DialectMySQL)
+	files, err := CSVFiles(tables)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "empty.csv", files[0].Name)
+	assert.Equal(t, "id\n", string(files[0].Content))
+	assert.Equal(t, "events.csv", files[1].Name)
+	assert.Equal(t, "id,name,active,at,payload,note\n"+
+		"1,it's,true,2024-01-01 00:00:00,00ff,\n"+
+		"2,b,false,2024-01-02,01,x\n"+
+		"3,c,true,10:00:00,02,\n", string(files[1].Content))
+}
@@ -0,0 +1,278 @@
+package fakedata
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+)
+
+// baseTime is the start of the range generated date-times fall in.
+var baseTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// bounds are the limits CHECK constraints put on a column.
+type bounds struct {
+	min, max *float64
+	// exclusive marks min or max as exclusive, from > and <.
+	minExclusive, maxExclusive bool
+	// values are the allowed values of an IN list.
+	values []string
+}
+
+var (
+	betweenRe    = regexp.MustCompile(`(?i)^(\w+)\s+BETWEEN\s+(-?[\d.]+)\s+AND\s+(-?[\d.]+)$`)
+	comparisonRe = regexp.MustCompile(`^(\w+)\s*(>=|<=|>|<|=)\s*(-?[\d.]+)$`)
+	reversedRe   = regexp.MustCompile(`^(-?[\d.]+)\s*(>=|<=|>|<|=)\s*(\w+)$`)
+	inListRe     = regexp.MustCompile(`(?i)^(\w+)\s+IN\s*\((.*)\)$`)
+	andRe        = regexp.MustCompile(`(?i)\s+AND\s+`)
+	orRe         = regexp.MustCompile(`(?i)\bOR\b`)
+	betweenTail  = regexp.MustCompile(`(?i)\bBETWEEN\s+\S+$`)
+	literalRe    = regexp.MustCompile(`'((?:[^']|'')*)'|(-?[\d.]+)`)
+)
+
+// reversedOps flips a comparison written as "0 < col".
+var reversedOps = map[string]string{">=": "<=", "<=": ">=", ">": "<", "<": ">", "=": "="}
+
+// checkBounds collects the bounds set by the CHECK constraints of t. Only
+// conjunctions of comparisons against numbers, BETWEEN and IN lists are
+// understood; other expressions are ignored.
+func checkBounds(t *core.Table) map[string]bounds {
+	out := make(map[string]bounds)
+	for _, c := range t.Constraints {
+		if c.Type != core.ConstraintCheck || orRe.MatchString(c.CheckExpression) {
+			continue
+		}
+		for _, part := range splitConjuncts(c.CheckExpression) {
+			applyBound(out, part)
+		}
+	}
+	return out
+}
+
+// splitConjuncts splits an expression at its ANDs, keeping the AND of a
+// BETWEEN with its operands.
+func splitConjuncts(expr string) []string {
+	pieces := andRe.Split(trimParens(expr), -1)
+	var parts []string
+	for i := 0; i < len(pieces); i++ {
+		p := trimParens(pieces[i])
+		if i+1 < len(pieces) && betweenTail.MatchString(p) {
+			p += " AND " + trimParens(pieces[i+1])
+			i++
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+func trimParens(s string) string {
+	s = strings.TrimSpace(s)
+	for strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") && strings.Count(s, "(") == 1 {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
+
+func applyBound(out map[string]bounds, part string) {
+	set := func(col, op string, v float64) {
+		b := out[col]
+		switch op {
+		case ">=", ">":
+			if b.min == nil || v > *b.min {
+				b.min, b.minExclusive = &v, op == ">"
+			}
+		case "<=", "<":
+			if b.max == nil || v < *b.max {
+				b.max, b.maxExclusive = &v, op == "<"
+			}
+		case "=":
+			b.min, b.max = &v, &v
+		}
+		out[col] = b
+	}
+	number := func(s string) (float64, bool) {
+		v, err := strconv.ParseFloat(s, 64)
+		return v, err == nil
+	}
+	if m := betweenRe.FindStringSubmatch(part); m != nil {
+		lo, ok1 := number(m[2])
+		hi, ok2 := number(m[3])
+		if ok1 && ok2 {
+			set(m[1], ">=", lo)
+			set(m[1], "<=", hi)
+		}
+		return
+	}
+	if m := comparisonRe.FindStringSubmatch(part); m != nil {
+		if v, ok := number(m[3]); ok {
+			set(m[1], m[2], v)
+		}
+		return
+	}
+	if m := reversedRe.FindStringSubmatch(part); m != nil {
+		if v, ok := number(m[1]); ok {
+			set(m[3], reversedOps[m[2]], v)
+		}
+		return
+	}
+	if m := inListRe.FindStringSubmatch(part); m != nil {
+		var values []string
+		for _, lit := range literalRe.FindAllStringSubmatch(m[2], -1) {
+			if lit[2] != "" {
+				values = append(values, lit[2])
+			} else {
+				values = append(values, strings.ReplaceAll(lit[1], "''", "'"))
+			}
+		}
+		b := out[m[1]]
+		b.values = values
+		out[m[1]] = b
+	}
+}
+
+// intRange returns the inclusive integer range allowed by b, starting from
+// the given defaults.
+func (b bounds) intRange(lo, hi int64) (int64, int64) {
+	if b.min != nil {
+		lo = int64(math.Ceil(*b.min))
+		if b.minExclusive && float64(lo) == *b.min {
+			lo++
+		}
+	}
+	if b.max != nil {
+		hi = int64(math.Floor(*b.max))
+		if b.maxExclusive && float64(hi) == *b.max {
+			hi--
+		}
+	}
+	return lo, max(hi, lo)
+}
+
+// intLimits are the largest values of the integer raw types smaller than INT.
+var intLimits = map[string]int64{"TINYINT": 127, "SMALLINT": 32767, "MEDIUMINT": 8388607}
+
+// value generates the value of a column that is not part of a foreign key.
+// Columns of a unique key (seq) are numbered by row so they never repeat.
+func (g *generator) value(c *core.Column, i int, seq bool, b bounds) Value {
+	if c.Nullable && !seq && g.rng.IntN(100) < nullPercent {
+		return Null
+	}
+	if len(b.values) > 0 {
+		return g.pick(c, b.values, i, seq)
+	}
+	switch c.Type {
+	case core.DataTypeInt:
+		lo, hi := b.intRange(1, 1000)
+		if limit, ok := intLimits[gen.RawBase(c)]; ok {
+			hi = min(hi, limit)
+		}
+		if seq {
+			start := lo
+			if c.IdentitySeed != 0 && b.min == nil {
+				start = c.IdentitySeed
+			}
+			step := max(c.IdentityIncrement, 1)
+			return Value{Kind: KindNumber, Text: strconv.FormatInt(start+int64(i)*step, 10)}
+		}
+		return Value{Kind: KindNumber, Text: strconv.FormatInt(lo+g.rng.Int64N(hi-lo+1), 10)}
+	case core.DataTypeFloat:
+		scale := 2
+		if p := gen.RawParams(c); len(p) == 2 {
+			scale = p[1]
+		}
+		lo, hi := b.intRange(0, 1000)
+		v := float64(lo) + g.rng.Float64()*float64(hi-lo)
+		if seq {
+			v = float64(lo + int64(i))
+		}
+		return Value{Kind: KindNumber, Text: strconv.FormatFloat(v, 'f', scale, 64)}
+	case core.DataTypeBoolean:
+		return Value{Kind: KindBool, Text: strconv.FormatBool(g.rng.IntN(2) == 0)}
+	case core.DataTypeEnum:
+		return g.pick(c, c.EnumValues, i, seq)
+	case core.DataTypeSet:
+		var members []string
+		for _, v := range c.EnumValues {
+			if g.rng.IntN(2) == 0 {
+				members = append(members, v)
+			}
+		}
+		if len(members) == 0 && len(c.EnumValues) > 0 {
+			members = c.EnumValues[:1]
+		}
+		return Value{Kind: KindString, Text: strings.Join(members, ",")}
+	case core.DataTypeDatetime:
+		offset := time.Duration(g.rng.Int64N(365*24*3600)) * time.Second
+		if seq {
+			offset = time.Duration(i) * time.Minute
+		}
+		ts := baseTime.Add(offset)
+		switch gen.RawBase(c) {
+		case "DATE":
+			if seq {
+				ts = baseTime.AddDate(0, 0, i)
+			}
+			return Value{Kind: KindDate, Text: ts.Format(time.DateOnly)}
+		case "TIME":
+			return Value{Kind: KindTime, Text: ts.Format(time.TimeOnly)}
+		}
+		return Value{Kind: KindDatetime, Text: ts.Format(time.DateTime)}
+	case core.DataTypeUUID:
+		var buf [16]byte
+		for j := range buf {
+			buf[j] = byte(g.rng.UintN(256))
+		}
+		buf[6] = buf[6]&0x0f | 0x40
+		buf[8] = buf[8]&0x3f | 0x80
+		h := hex.EncodeToString(buf[:])
+		return Value{Kind: KindString, Text: h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]}
+	case core.DataTypeJSON:
+		return Value{Kind: KindString, Text: fmt.Sprintf(`{"n": %d}`, g.rng.IntN(1000))}
+	case core.DataTypeBinary:
+		buf := make([]byte, 8)
+		for j := range buf {
+			buf[j] = byte(g.rng.UintN(256))
+		}
+		return Value{Kind: KindBinary, Text: hex.EncodeToString(buf)}
+	default:
+		return Value{Kind: KindString, Text: g.text(c, i, seq)}
+	}
+}
+
+// pick chooses one of values; unique columns cycle through them so the
+// retry in row only has to deal with running out of values.
+func (g *generator) pick(c *core.Column, values []string, i int, seq bool) Value {
+	v := values[g.rng.IntN(len(values))]
+	if seq {
+		v = values[i%len(values)]
+	}
+	if c.Type == core.DataTypeInt || c.Type == core.DataTypeFloat {
+		return Value{Kind: KindNumber, Text: v}
+	}
+	return Value{Kind: KindString, Text: v}
+}
+
+// text generates a string such as "name_17", or "email_17@example.com" for
+// columns named like an email address, cut to the length of a CHAR or
+// VARCHAR raw type.
+func (g *generator) text(c *core.Column, i int, seq bool) string {
+	n := i + 1
+	if !seq {
+		n = g.rng.IntN(1_000_000)
+	}
+	s := fmt.Sprintf("%s_%d", c.Name, n)
+	if strings.Contains(c.Name, "email") {
+		s = fmt.Sprintf("user_%d@example.com", n)
+	}
+	if p := gen.RawParams(c); len(p) == 1 && p[0] > 0 && len(s) > p[0] {
+		s = strconv.Itoa(n)
+		s = s[max(len(s)-p[0], 0):]
+	}
+	return s
+}
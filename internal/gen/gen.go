@@ -0,0 +1,66 @@
+// Package gen holds the types shared by the code generators that turn a
+// core.Database into source files for other languages and tools, and the
+// registry of schema exporters.
+package gen
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"smf/internal/core"
+)
+
+// File is a single generated source file.
+type File struct {
+	// Name is the file name relative to the output directory.
+	Name string
+	// Content is the generated source.
+	Content []byte
+}
+
+// SchemaExporter translates a validated core.Database into the schema format
+// of another tool (e.g. an ORM). Features the target cannot express must be
+// carried over as comments holding the original definition rather than
+// silently dropped.
+type SchemaExporter interface {
+	Export(db *core.Database) ([]File, error)
+}
+
+var (
+	registry = make(map[string]func() SchemaExporter)
+	mu       sync.RWMutex
+)
+
+// Register makes an exporter available under name. Exporter packages call it
+// from init.
+func Register(name string, fn func() SchemaExporter) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[name] = fn
+}
+
+// NewExporter returns the exporter registered under name.
+func NewExporter(name string) (SchemaExporter, error) {
+	mu.RLock()
+	fn, ok := registry[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported export target %q", name)
+	}
+
+	return fn(), nil
+}
+
+// Exporters returns the names of the registered exporters in sorted order.
+func Exporters() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
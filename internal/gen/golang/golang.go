@@ -0,0 +1,108 @@
+// Package golang generates Go model structs from a core.Database: one file
+// per table with a struct whose fields mirror the columns, plus a named
+// string type and constants for every enum column.
+package golang
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"text/template"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+)
+
+// NullableStyle selects how nullable columns are represented.
+type NullableStyle string
+
+const (
+	// NullablePointer maps nullable columns to pointer types (e.g. *string).
+	NullablePointer NullableStyle = "pointer"
+	// NullableSQL maps nullable columns to database/sql null types (e.g. sql.NullString).
+	NullableSQL NullableStyle = "sql"
+)
+
+// Options configures Generate.
+type Options struct {
+	// Package is the package clause of the generated files. Defaults to "models".
+	Package string
+	// Nullable selects the representation of nullable columns. Defaults to NullablePointer.
+	Nullable NullableStyle
+	// Template replaces the embedded default template. It must define a
+	// template named "file", which is executed once per table.
+	Template string
+}
+
+//go:embed model.go.tmpl
+var defaultTemplate string
+
+// Generate renders one gofmt-formatted Go file per table, in table name order.
+// Each file is named after its table with a "_model.go" suffix, e.g.
+// "order_items_model.go". It fails when two columns of a table, or two
+// tables, map to the same Go name.
+func Generate(db *core.Database, opts Options) ([]gen.File, error) {
+	if opts.Package == "" {
+		opts.Package = "models"
+	}
+	if opts.Nullable == "" {
+		opts.Nullable = NullablePointer
+	}
+	if opts.Nullable != NullablePointer && opts.Nullable != NullableSQL {
+		return nil, fmt.Errorf("golang: unsupported nullable style %q", opts.Nullable)
+	}
+
+	text := defaultTemplate
+	if opts.Template != "" {
+		text = opts.Template
+	}
+	tmpl, err := template.New("model").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("golang: parse template: %w", err)
+	}
+
+	tables := slices.Clone(db.Tables)
+	slices.SortFunc(tables, func(a, b *core.Table) int { return strings.Compare(a.Name, b.Name) })
+
+	files := make([]gen.File, 0, len(tables))
+	// owners maps the generated file and type names to the table they belong to.
+	owners := make(map[string]string)
+	claim := func(kind, name, table string) error {
+		if prev, ok := owners[kind+" "+name]; ok {
+			return fmt.Errorf("golang: tables %q and %q both map to %s %s", prev, table, kind, name)
+		}
+		owners[kind+" "+name] = table
+		return nil
+	}
+	for _, t := range tables {
+		data, err := buildFile(t, opts)
+		if err != nil {
+			return nil, fmt.Errorf("golang: table %q: %w", t.Name, err)
+		}
+		name := fileName(t.Name)
+		if err := claim("file", name, t.Name); err != nil {
+			return nil, err
+		}
+		if err := claim("type", data.Struct.Name, t.Name); err != nil {
+			return nil, err
+		}
+		for _, e := range data.Enums {
+			if err := claim("type", e.Name, t.Name); err != nil {
+				return nil, err
+			}
+		}
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, "file", data); err != nil {
+			return nil, fmt.Errorf("golang: render table %q: %w", t.Name, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("golang: format table %q: %w", t.Name, err)
+		}
+		files = append(files, gen.File{Name: name, Content: src})
+	}
+	return files, nil
+}
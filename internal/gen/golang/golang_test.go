@@ -0,0 +1,224 @@
+package golang
+
+import (
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+	"smf/internal/parser/toml"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+const modelsSchema = `
+[database]
+name    = "shop"
+dialect = "postgresql"
+
+[[tables]]
+name    = "orders"
+comment = "Customer orders."
+
+  [tables.timestamps]
+  enabled = true
+
+  [[tables.columns]]
+  name           = "id"
+  type           = "bigint"
+  primary_key    = true
+
+  [[tables.columns]]
+  name       = "user_id"
+  type       = "uuid"
+  comment    = "Owner of the order."
+
+  [[tables.columns]]
+  name     = "status"
+  type     = "enum"
+  values   = ["pending", "in-progress", "shipped"]
+
+  [[tables.columns]]
+  name     = "priority"
+  type     = "enum"
+  values   = ["low", "high"]
+  nullable = true
+
+  [[tables.columns]]
+  name     = "total"
+  type     = "decimal(10,2)"
+  nullable = true
+
+  [[tables.columns]]
+  name     = "gift"
+  type     = "boolean"
+  nullable = true
+
+  [[tables.columns]]
+  name     = "shipped_at"
+  type     = "timestamp"
+  nullable = true
+
+  [[tables.columns]]
+  name     = "metadata"
+  type     = "json"
+  nullable = true
+
+  [[tables.columns]]
+  name = "receipt_pdf"
+  type = "blob"
+  nullable = true
+`
+
+func generate(t *testing.T, opts Options) []gen.File {
+	t.Helper()
+	db, err := toml.NewParser().Parse(strings.NewReader(modelsSchema))
+	require.NoError(t, err)
+	files, err := Generate(db, opts)
+	require.NoError(t, err)
+	return files
+}
+
+func goldenPath(name string) string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..", "..", "test", "data", "gen", "go", name)
+}
+
+// typeCheck parses and type-checks the generated files as one package.
+func typeCheck(t *testing.T, files []gen.File) {
+	t.Helper()
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for _, f := range files {
+		af, err := parser.ParseFile(fset, f.Name, f.Content, parser.ParseComments)
+		require.NoError(t, err, f.Name)
+		parsed = append(parsed, af)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err := conf.Check("models", fset, parsed, nil)
+	require.NoError(t, err)
+}
+
+func TestGenerateGolden(t *testing.T) {
+	files := generate(t, Options{})
+	require.Len(t, files, 1)
+	assert.Equal(t, "orders_model.go", files[0].Name)
+
+	path := goldenPath("orders.go.golden")
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, files[0].Content, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file; run go test ./internal/gen/golang -update")
+	assert.Equal(t, string(want), string(files[0].Content))
+}
+
+func TestGenerateIsGofmtClean(t *testing.T) {
+	for _, style := range []NullableStyle{NullablePointer, NullableSQL} {
+		for _, f := range generate(t, Options{Nullable: style}) {
+			formatted, err := format.Source(f.Content)
+			require.NoError(t, err)
+			assert.Equal(t, string(formatted), string(f.Content), "%s (%s)", f.Name, style)
+		}
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	typeCheck(t, generate(t, Options{}))
+	typeCheck(t, generate(t, Options{Nullable: NullableSQL}))
+}
+
+func TestGenerateNullableSQL(t *testing.T) {
+	src := string(generate(t, Options{Nullable: NullableSQL, Package: "db"})[0].Content)
+	assert.Contains(t, src, "package db\n")
+	assert.Regexp(t, `Total\s+sql\.NullFloat64 `, src)
+	assert.Regexp(t, `ShippedAt\s+sql\.NullTime `, src)
+	assert.Regexp(t, `Priority\s+sql\.Null\[OrdersPriority\] `, src)
+	assert.Regexp(t, `Metadata\s+json\.RawMessage `, src)
+	// time is still needed for the NOT NULL timestamp columns.
+	assert.Contains(t, src, `"time"`)
+}
+
+func TestGenerateCustomTemplate(t *testing.T) {
+	const tmpl = `{{define "file"}}package {{.Package}}
+
+// {{.Struct.Name}} has {{len .Struct.Fields}} columns.
+type {{.Struct.Name}} struct{}
+{{end}}`
+	files := generate(t, Options{Template: tmpl})
+	assert.Equal(t, "package models\n\n// Orders has 11 columns.\ntype Orders struct{}\n", string(files[0].Content))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	db := &core.Database{Tables: []*core.Table{{Name: "t"}}}
+
+	_, err := Generate(db, Options{Nullable: "optional"})
+	assert.ErrorContains(t, err, `unsupported nullable style "optional"`)
+
+	_, err = Generate(db, Options{Template: `{{define "file"}}`})
+	assert.ErrorContains(t, err, "parse template")
+
+	_, err = Generate(db, Options{Template: `{{define "file"}}not go{{end}}`})
+	assert.ErrorContains(t, err, `format table "t"`)
+
+	db = &core.Database{Tables: []*core.Table{{
+		Name:    "users",
+		Columns: []*core.Column{{Name: "user_id", Type: core.DataTypeInt}, {Name: "user-id", Type: core.DataTypeInt}},
+	}}}
+	_, err = Generate(db, Options{})
+	assert.EqualError(t, err, `golang: table "users": columns "user_id" and "user-id" both map to field UserID`)
+
+	db = &core.Database{Tables: []*core.Table{{Name: "order_items"}, {Name: "OrderItems"}}}
+	_, err = Generate(db, Options{})
+	assert.EqualError(t, err, `golang: tables "OrderItems" and "order_items" both map to type OrderItems`)
+
+	db = &core.Database{Tables: []*core.Table{
+		{Name: "order", Columns: []*core.Column{{Name: "status", Type: core.DataTypeEnum, EnumValues: []string{"new"}}}},
+		{Name: "order_status"},
+	}}
+	_, err = Generate(db, Options{})
+	assert.EqualError(t, err, `golang: tables "order" and "order_status" both map to type OrderStatus`)
+}
+
+func TestFileName(t *testing.T) {
+	tests := map[string]string{
+		"orders":      "orders_model.go",
+		"user_test":   "user_test_model.go",
+		"event_linux": "event_linux_model.go",
+		"log_amd64":   "log_amd64_model.go",
+		"_migrations": "migrations_model.go",
+		"OrderItems":  "orderitems_model.go",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, fileName(in), in)
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":     "UserID",
+		"api_key":     "APIKey",
+		"created_at":  "CreatedAt",
+		"2fa_enabled": "X2faEnabled",
+		"order_items": "OrderItems",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, exportedName(in), in)
+	}
+	assert.Equal(t, "InProgress", valueName("in-progress"))
+	assert.Equal(t, "Empty", valueName(""))
+}
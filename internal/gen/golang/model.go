@@ -0,0 +1,202 @@
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+)
+
+// fileData is the value passed to the "file" template.
+type fileData struct {
+	Package string
+	Imports []string
+	Struct  structData
+	Enums   []enumData
+}
+
+type structData struct {
+	Name    string
+	Table   string
+	Comment string
+	Fields  []fieldData
+}
+
+type fieldData struct {
+	Name    string
+	Type    string
+	Column  string
+	Comment string
+	Tag     string
+}
+
+type enumData struct {
+	Name   string
+	Column string
+	Values []enumValue
+}
+
+type enumValue struct {
+	Name  string
+	Value string
+}
+
+func buildFile(t *core.Table, opts Options) (fileData, error) {
+	structName := exportedName(t.Name)
+	data := fileData{
+		Package: opts.Package,
+		Struct:  structData{Name: structName, Table: t.Name, Comment: oneLine(t.Comment)},
+	}
+
+	imports := make(map[string]bool)
+	fields := make(map[string]string, len(t.Columns))
+	for _, c := range t.Columns {
+		name := exportedName(c.Name)
+		if prev, ok := fields[name]; ok {
+			return fileData{}, fmt.Errorf("columns %q and %q both map to field %s", prev, c.Name, name)
+		}
+		fields[name] = c.Name
+		f := fieldData{
+			Name:    name,
+			Column:  c.Name,
+			Comment: oneLine(c.Comment),
+			Tag:     fmt.Sprintf("`db:%q json:%q`", c.Name, c.Name),
+		}
+		if c.Type == core.DataTypeEnum && len(c.EnumValues) > 0 {
+			e := buildEnum(structName, c)
+			data.Enums = append(data.Enums, e)
+			f.Type = nullableNamed(e.Name, c.Nullable, opts.Nullable, imports)
+		} else {
+			f.Type = fieldType(c, opts.Nullable, imports)
+		}
+		data.Struct.Fields = append(data.Struct.Fields, f)
+	}
+
+	for pkg := range imports {
+		data.Imports = append(data.Imports, pkg)
+	}
+	slices.Sort(data.Imports)
+	return data, nil
+}
+
+// fileName returns the name of the file generated for a table: the table name
+// in snake case with a "_model.go" suffix, so that names such as "user_test"
+// or "event_linux" do not turn into test or build-constrained files.
+func fileName(table string) string {
+	stem := strings.ToLower(strings.Join(gen.SplitWords(table), "_"))
+	if stem == "" {
+		stem = "x"
+	}
+	return stem + "_model.go"
+}
+
+// goType describes the Go representation of a portable data type.
+type goType struct {
+	base    string // type for NOT NULL columns
+	null    string // database/sql type for nullable columns in NullableSQL style
+	imports []string
+	// nilable types (slices) represent NULL as nil in every style.
+	nilable bool
+}
+
+var goTypes = map[core.DataType]goType{
+	core.DataTypeString:   {base: "string", null: "sql.NullString"},
+	core.DataTypeUUID:     {base: "string", null: "sql.NullString"},
+	core.DataTypeInt:      {base: "int64", null: "sql.NullInt64"},
+	core.DataTypeFloat:    {base: "float64", null: "sql.NullFloat64"},
+	core.DataTypeBoolean:  {base: "bool", null: "sql.NullBool"},
+	core.DataTypeDatetime: {base: "time.Time", null: "sql.NullTime", imports: []string{"time"}},
+	core.DataTypeJSON:     {base: "json.RawMessage", imports: []string{"encoding/json"}, nilable: true},
+	core.DataTypeBinary:   {base: "[]byte", nilable: true},
+	core.DataTypeEnum:     {base: "string", null: "sql.NullString"},
+	core.DataTypeSet:      {base: "string", null: "sql.NullString"},
+}
+
+func fieldType(c *core.Column, style NullableStyle, imports map[string]bool) string {
+	gt, ok := goTypes[c.Type]
+	if !ok {
+		return "any"
+	}
+	if c.Nullable && !gt.nilable && style == NullableSQL {
+		imports["database/sql"] = true
+		return gt.null
+	}
+	for _, pkg := range gt.imports {
+		imports[pkg] = true
+	}
+	if c.Nullable && !gt.nilable {
+		return "*" + gt.base
+	}
+	return gt.base
+}
+
+// nullableNamed returns the field type for a column of a generated named type.
+func nullableNamed(name string, nullable bool, style NullableStyle, imports map[string]bool) string {
+	switch {
+	case !nullable:
+		return name
+	case style == NullableSQL:
+		imports["database/sql"] = true
+		return "sql.Null[" + name + "]"
+	default:
+		return "*" + name
+	}
+}
+
+func buildEnum(structName string, c *core.Column) enumData {
+	e := enumData{Name: structName + exportedName(c.Name), Column: c.Name}
+	seen := make(map[string]bool, len(c.EnumValues))
+	for _, v := range c.EnumValues {
+		name := e.Name + valueName(v)
+		for i := 2; seen[name]; i++ {
+			name = fmt.Sprintf("%s%s%d", e.Name, valueName(v), i)
+		}
+		seen[name] = true
+		e.Values = append(e.Values, enumValue{Name: name, Value: fmt.Sprintf("%q", v)})
+	}
+	return e
+}
+
+// initialisms are rendered in upper case, following Go naming conventions.
+var initialisms = map[string]bool{
+	"api": true, "db": true, "html": true, "http": true, "id": true, "ip": true,
+	"json": true, "sql": true, "ttl": true, "uid": true, "url": true, "uri": true,
+	"uuid": true, "xml": true,
+}
+
+// exportedName converts a snake_case identifier to an exported Go name,
+// e.g. "user_id" -> "UserID".
+func exportedName(name string) string {
+	var sb strings.Builder
+	for _, part := range gen.SplitWords(name) {
+		if initialisms[strings.ToLower(part)] {
+			sb.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	out := sb.String()
+	if out == "" || !unicode.IsLetter([]rune(out)[0]) {
+		out = "X" + out
+	}
+	return out
+}
+
+// valueName converts an enum value such as "in-progress" to "InProgress".
+func valueName(v string) string {
+	name := exportedName(strings.ToLower(v))
+	if name == "X" {
+		return "Empty"
+	}
+	return name
+}
+
+// oneLine folds a comment onto a single line so it fits a // comment.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
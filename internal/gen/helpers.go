@@ -0,0 +1,169 @@
+package gen
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"smf/internal/core"
+)
+
+// SplitWords splits an identifier such as "order_items" or "in-progress" into
+// its words.
+func SplitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// PascalCase converts an identifier to PascalCase, e.g. "order_items" -> "OrderItems".
+func PascalCase(s string) string {
+	var sb strings.Builder
+	for _, w := range SplitWords(s) {
+		runes := []rune(w)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	return sb.String()
+}
+
+// CamelCase converts an identifier to camelCase, e.g. "user_id" -> "userId".
+func CamelCase(s string) string {
+	p := []rune(PascalCase(s))
+	if len(p) == 0 {
+		return ""
+	}
+	p[0] = unicode.ToLower(p[0])
+	return string(p)
+}
+
+// DefaultKind classifies a column default, see ClassifyDefault.
+type DefaultKind string
+
+const (
+	DefaultNone             DefaultKind = "none"
+	DefaultString           DefaultKind = "string"
+	DefaultNumber           DefaultKind = "number"
+	DefaultBool             DefaultKind = "bool"
+	DefaultEnum             DefaultKind = "enum"
+	DefaultCurrentTimestamp DefaultKind = "current_timestamp"
+	DefaultExpression       DefaultKind = "expression"
+)
+
+var numberRe = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// ClassifyDefault tells generators how to render the default of c. The
+// schema stores defaults as strings, so the column type decides whether a
+// value is a literal or a SQL expression. The returned value is normalized
+// for its kind: "true"/"false" for booleans and unquoted text for strings.
+func ClassifyDefault(c *core.Column) (kind DefaultKind, value string) {
+	if c.DefaultValue == nil {
+		return DefaultNone, ""
+	}
+	v := strings.TrimSpace(*c.DefaultValue)
+	upper := strings.ToUpper(v)
+	if upper == "CURRENT_TIMESTAMP" || upper == "CURRENT_TIMESTAMP()" || upper == "NOW()" {
+		return DefaultCurrentTimestamp, v
+	}
+	switch c.Type {
+	case core.DataTypeBoolean:
+		switch upper {
+		case "TRUE", "1":
+			return DefaultBool, "true"
+		case "FALSE", "0":
+			return DefaultBool, "false"
+		}
+	case core.DataTypeInt, core.DataTypeFloat:
+		if numberRe.MatchString(v) {
+			return DefaultNumber, v
+		}
+	case core.DataTypeEnum:
+		if slices.Contains(c.EnumValues, unquote(v)) {
+			return DefaultEnum, unquote(v)
+		}
+	case core.DataTypeString, core.DataTypeUUID, core.DataTypeSet:
+		if !looksLikeExpression(v) {
+			return DefaultString, unquote(v)
+		}
+	}
+	return DefaultExpression, v
+}
+
+// looksLikeExpression reports whether v is a function call such as
+// "gen_random_uuid()" rather than a literal.
+func looksLikeExpression(v string) bool {
+	open := strings.Index(v, "(")
+	return open > 0 && strings.HasSuffix(v, ")") && !strings.HasPrefix(v, "'")
+}
+
+func unquote(v string) string {
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
+	return v
+}
+
+// IsUniqueKey reports whether columns exactly match the primary key or a
+// unique constraint of t, i.e. a foreign key on them is one-to-one.
+func IsUniqueKey(t *core.Table, columns []string) bool {
+	for _, c := range t.Constraints {
+		if c.Type != core.ConstraintPrimaryKey && c.Type != core.ConstraintUnique {
+			continue
+		}
+		if len(c.Expressions) > 0 {
+			continue
+		}
+		if sameColumns(c.Columns, columns) {
+			return true
+		}
+	}
+	for _, idx := range t.Indexes {
+		if idx.Unique && sameColumns(idx.Names(), columns) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// RawBase returns the upper-cased base name of c.RawType (e.g. "BIGINT" for
+// "bigint unsigned"), or "" when the column has no raw type.
+func RawBase(c *core.Column) string {
+	base, _, _ := strings.Cut(strings.TrimSpace(c.RawType), "(")
+	base, _, _ = strings.Cut(base, " ")
+	return strings.ToUpper(base)
+}
+
+// RawParams returns the comma-separated numeric parameters of the column's raw
+// type, e.g. [10 2] for DECIMAL(10,2). Non-numeric parameters (such as ENUM
+// values) yield nil.
+func RawParams(c *core.Column) []int {
+	_, rest, ok := strings.Cut(c.RawType, "(")
+	if !ok {
+		return nil
+	}
+	inner, _, ok := strings.Cut(rest, ")")
+	if !ok {
+		return nil
+	}
+	var params []int
+	for p := range strings.SplitSeq(inner, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil
+		}
+		params = append(params, n)
+	}
+	return params
+}
@@ -0,0 +1,547 @@
+// Package prisma exports a core.Database as a Prisma schema (schema.prisma).
+// Tables become models, foreign keys become @relation fields with their back
+// relations, and enum columns become Prisma enums. Features Prisma cannot
+// express, such as CHECK constraints, are kept as comments holding the
+// original definition.
+package prisma
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+)
+
+func init() {
+	gen.Register("prisma", New)
+}
+
+type exporter struct{}
+
+// New returns the Prisma schema exporter.
+func New() gen.SchemaExporter {
+	return &exporter{}
+}
+
+// providers maps smf dialects to Prisma datasource providers.
+var providers = map[core.Dialect]string{
+	core.DialectMySQL:      "mysql",
+	core.DialectMariaDB:    "mysql",
+	core.DialectTiDB:       "mysql",
+	core.DialectPostgreSQL: "postgresql",
+	core.DialectSQLite:     "sqlite",
+	core.DialectMSSQL:      "sqlserver",
+}
+
+var referentialActions = map[core.ReferentialAction]string{
+	core.RefActionCascade:    "Cascade",
+	core.RefActionRestrict:   "Restrict",
+	core.RefActionSetNull:    "SetNull",
+	core.RefActionSetDefault: "SetDefault",
+	core.RefActionNoAction:   "NoAction",
+}
+
+var identRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func (e *exporter) Export(db *core.Database) ([]gen.File, error) {
+	s := newSchema(db)
+	var sb strings.Builder
+	sb.WriteString("// Code generated by smf gen prisma. DO NOT EDIT.\n\n")
+	s.writeHeader(&sb, db)
+	for _, m := range s.models {
+		sb.WriteString("\n")
+		m.write(&sb)
+	}
+	for _, en := range s.enums {
+		sb.WriteString("\n")
+		en.write(&sb)
+	}
+	return []gen.File{{Name: "schema.prisma", Content: []byte(sb.String())}}, nil
+}
+
+func (s *schema) writeHeader(sb *strings.Builder, db *core.Database) {
+	sb.WriteString("generator client {\n  provider = \"prisma-client-js\"\n}\n\n")
+
+	dialect := ""
+	if db.Dialect != nil {
+		dialect = string(*db.Dialect)
+	}
+	provider, ok := providers[core.Dialect(dialect)]
+	if !ok {
+		fmt.Fprintf(sb, "// Dialect %q has no Prisma provider; set one and uncomment:\n", dialect)
+		sb.WriteString("// datasource db {\n//   provider = \"\"\n//   url      = env(\"DATABASE_URL\")\n// }\n")
+		return
+	}
+	fmt.Fprintf(sb, "datasource db {\n  provider = %q\n  url      = env(\"DATABASE_URL\")\n}\n", provider)
+}
+
+// schema is the intermediate form of the whole Prisma document.
+type schema struct {
+	models []*model
+	enums  []*enum
+	byName map[string]*model
+}
+
+type model struct {
+	name       string
+	table      string
+	comment    string
+	fields     []*field
+	attributes []string
+	comments   []string
+	used       map[string]bool
+}
+
+type field struct {
+	name     string
+	column   string // database column of a scalar field, empty for relations
+	typ      string
+	attrs    []string
+	doc      string
+	comments []string
+}
+
+type enum struct {
+	name    string
+	dbName  string // name of a database-level enum type, mapped with @@map
+	members []string
+}
+
+func newSchema(db *core.Database) *schema {
+	s := &schema{byName: make(map[string]*model)}
+	tables := slices.Clone(db.Tables)
+	slices.SortFunc(tables, func(a, b *core.Table) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, t := range tables {
+		m := s.buildModel(t)
+		s.models = append(s.models, m)
+		s.byName[t.Name] = m
+	}
+	for _, t := range tables {
+		s.addRelations(t)
+	}
+	return s
+}
+
+func (s *schema) buildModel(t *core.Table) *model {
+	m := &model{
+		name:    modelName(t.Name),
+		table:   t.Name,
+		comment: t.Comment,
+		used:    make(map[string]bool),
+	}
+
+	var pk []string
+	if c := t.PrimaryKey(); c != nil {
+		pk = c.Columns
+	}
+	uniques := singleColumnUniques(t)
+
+	for _, c := range t.Columns {
+		f := &field{name: m.reserve(gen.CamelCase(c.Name)), column: c.Name, doc: c.Comment}
+		f.typ = s.fieldType(m, c)
+		if c.Nullable {
+			f.typ += "?"
+		}
+		if len(pk) == 1 && pk[0] == c.Name {
+			f.attrs = append(f.attrs, "@id")
+		}
+		if d := defaultAttr(c, f.typ); d != "" {
+			f.attrs = append(f.attrs, d)
+		}
+		if name, ok := uniques[c.Name]; ok {
+			f.attrs = append(f.attrs, fmt.Sprintf("@unique(map: %q)", name))
+		}
+		if f.name != c.Name {
+			f.attrs = append(f.attrs, fmt.Sprintf("@map(%q)", c.Name))
+		}
+		if c.IsGenerated {
+			f.comments = append(f.comments, fmt.Sprintf("Not supported by Prisma: GENERATED ALWAYS AS (%s) %s", c.GenerationExpression, c.GenerationStorage))
+		}
+		m.fields = append(m.fields, f)
+	}
+
+	if len(pk) > 1 {
+		m.attributes = append(m.attributes, fmt.Sprintf("@@id([%s])", m.fieldList(pk)))
+	}
+	for _, c := range t.Constraints {
+		switch c.Type {
+		case core.ConstraintUnique:
+			if len(c.Expressions) > 0 || c.NullsNotDistinct {
+				m.comments = append(m.comments, fmt.Sprintf("Not supported by Prisma: CONSTRAINT %s %s", c.Name, uniqueDefinition(c)))
+				continue
+			}
+			if len(c.Columns) > 1 {
+				m.attributes = append(m.attributes, fmt.Sprintf("@@unique([%s], map: %q)", m.fieldList(c.Columns), c.Name))
+			}
+		case core.ConstraintCheck:
+			m.comments = append(m.comments, fmt.Sprintf("Not supported by Prisma: CONSTRAINT %s CHECK (%s)", c.Name, c.CheckExpression))
+		case core.ConstraintExclusion:
+			m.comments = append(m.comments, fmt.Sprintf("Not supported by Prisma: CONSTRAINT %s %s", c.Name, exclusionDefinition(c)))
+		}
+	}
+	for _, idx := range t.Indexes {
+		m.addIndex(idx)
+	}
+	m.attributes = append(m.attributes, fmt.Sprintf("@@map(%q)", t.Name))
+	return m
+}
+
+func (m *model) addIndex(idx *core.Index) {
+	if idx.Type != "" && idx.Type != core.IndexTypeBTree {
+		m.comments = append(m.comments, fmt.Sprintf("Not supported by Prisma: %s INDEX %s (%s)", idx.Type, idx.Name, strings.Join(idx.Names(), ", ")))
+		return
+	}
+	cols := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		var args []string
+		if c.Length > 0 {
+			args = append(args, "length: "+strconv.Itoa(c.Length))
+		}
+		if c.Order == core.SortDesc {
+			args = append(args, "sort: Desc")
+		}
+		cols[i] = m.fieldFor(c.Name)
+		if len(args) > 0 {
+			cols[i] += "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	attr := "@@index"
+	if idx.Unique {
+		attr = "@@unique"
+	}
+	m.attributes = append(m.attributes, fmt.Sprintf("%s([%s], map: %q)", attr, strings.Join(cols, ", "), idx.Name))
+}
+
+// addRelations adds a relation field for every foreign key of t, plus the
+// back relation Prisma requires on the referenced model.
+func (s *schema) addRelations(t *core.Table) {
+	m := s.byName[t.Name]
+	fks := foreignKeys(t)
+	for _, fk := range fks {
+		target, ok := s.byName[fk.ReferencedTable]
+		if !ok {
+			m.comments = append(m.comments, fmt.Sprintf("Not supported by Prisma: CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s) targets an undeclared table",
+				fk.Name, strings.Join(fk.Columns, ", "), fk.ReferencedTable, strings.Join(fk.ReferencedColumns, ", ")))
+			continue
+		}
+
+		// Prisma needs a relation name when a pair of models has several
+		// relations, including every self-relation.
+		named := target == m || countTargets(fks, fk.ReferencedTable) > 1
+
+		optional := false
+		for _, col := range fk.Columns {
+			if c := t.FindColumn(col); c != nil && c.Nullable {
+				optional = true
+			}
+		}
+
+		args := []string{
+			fmt.Sprintf("fields: [%s]", m.fieldList(fk.Columns)),
+			fmt.Sprintf("references: [%s]", target.fieldList(fk.ReferencedColumns)),
+		}
+		if a, ok := referentialActions[fk.OnDelete]; ok {
+			args = append(args, "onDelete: "+a)
+		}
+		if a, ok := referentialActions[fk.OnUpdate]; ok {
+			args = append(args, "onUpdate: "+a)
+		}
+		args = append(args, fmt.Sprintf("map: %q", fk.Name))
+		if named {
+			args = append([]string{strconv.Quote(fk.Name)}, args...)
+		}
+
+		typ := target.name
+		if optional {
+			typ += "?"
+		}
+		m.fields = append(m.fields, &field{
+			name:  m.reserve(relationName(fk, target.name)),
+			typ:   typ,
+			attrs: []string{"@relation(" + strings.Join(args, ", ") + ")"},
+		})
+
+		back := &field{name: target.reserve(backRelationName(m.name, fk, named)), typ: m.name + "[]"}
+		if gen.IsUniqueKey(t, fk.Columns) {
+			back.typ = m.name + "?"
+		}
+		if named {
+			back.attrs = []string{fmt.Sprintf("@relation(%q)", fk.Name)}
+		}
+		target.fields = append(target.fields, back)
+	}
+}
+
+func (s *schema) fieldType(m *model, c *core.Column) string {
+	switch c.Type {
+	case core.DataTypeString, core.DataTypeUUID, core.DataTypeSet:
+		return "String"
+	case core.DataTypeInt:
+		if gen.RawBase(c) == "BIGINT" {
+			return "BigInt"
+		}
+		return "Int"
+	case core.DataTypeFloat:
+		switch gen.RawBase(c) {
+		case "DECIMAL", "NUMERIC", "NUMBER":
+			return "Decimal"
+		}
+		return "Float"
+	case core.DataTypeBoolean:
+		return "Boolean"
+	case core.DataTypeDatetime:
+		return "DateTime"
+	case core.DataTypeJSON:
+		return "Json"
+	case core.DataTypeBinary:
+		return "Bytes"
+	case core.DataTypeEnum:
+		if c.UserType != "" {
+			return s.sharedEnum(c)
+		}
+		if len(c.EnumValues) > 0 {
+			en := &enum{name: m.name + gen.PascalCase(c.Name)}
+			for _, v := range c.EnumValues {
+				en.members = append(en.members, enumMember(v))
+			}
+			s.enums = append(s.enums, en)
+			return en.name
+		}
+		return "String"
+	default:
+		raw := c.RawType
+		if raw == "" {
+			raw = string(c.Type)
+		}
+		return fmt.Sprintf("Unsupported(%q)", raw)
+	}
+}
+
+func defaultAttr(c *core.Column, typ string) string {
+	if c.AutoIncrement {
+		return "@default(autoincrement())"
+	}
+	kind, v := gen.ClassifyDefault(c)
+	switch kind {
+	case gen.DefaultNone:
+		return ""
+	case gen.DefaultCurrentTimestamp:
+		if strings.HasPrefix(typ, "DateTime") {
+			return "@default(now())"
+		}
+	case gen.DefaultString:
+		return fmt.Sprintf("@default(%s)", strconv.Quote(v))
+	case gen.DefaultNumber, gen.DefaultBool:
+		return fmt.Sprintf("@default(%s)", v)
+	case gen.DefaultEnum:
+		return fmt.Sprintf("@default(%s)", enumMemberName(v))
+	}
+	return fmt.Sprintf("@default(dbgenerated(%s))", strconv.Quote(v))
+}
+
+// reserve returns name, made unique within the model.
+func (m *model) reserve(name string) string {
+	if name == "" {
+		name = "field"
+	}
+	if !identRe.MatchString(name) {
+		name = "f" + name
+	}
+	candidate := name
+	for i := 2; m.used[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	m.used[candidate] = true
+	return candidate
+}
+
+// fieldFor returns the field name generated for column col.
+func (m *model) fieldFor(col string) string {
+	for _, f := range m.fields {
+		if f.column == col {
+			return f.name
+		}
+	}
+	return gen.CamelCase(col)
+}
+
+func (m *model) fieldList(cols []string) string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = m.fieldFor(c)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (m *model) write(sb *strings.Builder) {
+	if m.comment != "" {
+		fmt.Fprintf(sb, "/// %s\n", oneLine(m.comment))
+	}
+	fmt.Fprintf(sb, "model %s {\n", m.name)
+
+	nameWidth, typeWidth := 0, 0
+	for _, f := range m.fields {
+		nameWidth = max(nameWidth, len(f.name))
+		typeWidth = max(typeWidth, len(f.typ))
+	}
+	for _, f := range m.fields {
+		if f.doc != "" {
+			fmt.Fprintf(sb, "  /// %s\n", oneLine(f.doc))
+		}
+		for _, c := range f.comments {
+			fmt.Fprintf(sb, "  // %s\n", oneLine(c))
+		}
+		line := fmt.Sprintf("  %-*s %-*s %s", nameWidth, f.name, typeWidth, f.typ, strings.Join(f.attrs, " "))
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	if len(m.attributes) > 0 || len(m.comments) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, c := range m.comments {
+		fmt.Fprintf(sb, "  // %s\n", oneLine(c))
+	}
+	for _, a := range m.attributes {
+		fmt.Fprintf(sb, "  %s\n", a)
+	}
+	sb.WriteString("}\n")
+}
+
+// sharedEnum returns the Prisma enum of a column declared with a
+// database-level enum type, creating it on first use.
+func (s *schema) sharedEnum(c *core.Column) string {
+	for _, en := range s.enums {
+		if en.dbName == c.UserType {
+			return en.name
+		}
+	}
+	en := &enum{name: modelName(c.UserType), dbName: c.UserType}
+	for _, v := range c.EnumValues {
+		en.members = append(en.members, enumMember(v))
+	}
+	s.enums = append(s.enums, en)
+	return en.name
+}
+
+func (en *enum) write(sb *strings.Builder) {
+	fmt.Fprintf(sb, "enum %s {\n", en.name)
+	for _, m := range en.members {
+		fmt.Fprintf(sb, "  %s\n", m)
+	}
+	if en.dbName != "" && en.dbName != en.name {
+		fmt.Fprintf(sb, "\n  @@map(%q)\n", en.dbName)
+	}
+	sb.WriteString("}\n")
+}
+
+func modelName(table string) string {
+	name := gen.PascalCase(table)
+	if !identRe.MatchString(name) {
+		name = "M" + name
+	}
+	return name
+}
+
+// enumMember renders an enum value, mapping values that are not valid
+// Prisma identifiers.
+func enumMember(v string) string {
+	name := enumMemberName(v)
+	if name == v {
+		return name
+	}
+	return fmt.Sprintf("%s @map(%q)", name, v)
+}
+
+func enumMemberName(v string) string {
+	if identRe.MatchString(v) {
+		return v
+	}
+	name := strings.Join(gen.SplitWords(v), "_")
+	if !identRe.MatchString(name) {
+		name = "v_" + name
+	}
+	return name
+}
+
+// relationName names the relation field of fk: "user" for user_id, or the
+// target model in camelCase for composite keys.
+func relationName(fk *core.Constraint, target string) string {
+	if len(fk.Columns) == 1 {
+		if base, ok := strings.CutSuffix(fk.Columns[0], "_id"); ok && base != "" {
+			return gen.CamelCase(base)
+		}
+	}
+	return gen.CamelCase(target)
+}
+
+func backRelationName(source string, fk *core.Constraint, named bool) string {
+	name := gen.CamelCase(source)
+	if named {
+		name += gen.PascalCase(strings.Join(fk.Columns, "_"))
+	}
+	return name
+}
+
+func foreignKeys(t *core.Table) []*core.Constraint {
+	var fks []*core.Constraint
+	for _, c := range t.Constraints {
+		if c.Type == core.ConstraintForeignKey {
+			fks = append(fks, c)
+		}
+	}
+	return fks
+}
+
+func countTargets(fks []*core.Constraint, table string) int {
+	n := 0
+	for _, fk := range fks {
+		if fk.ReferencedTable == table {
+			n++
+		}
+	}
+	return n
+}
+
+// singleColumnUniques maps columns with a single-column UNIQUE constraint to
+// the constraint name.
+func singleColumnUniques(t *core.Table) map[string]string {
+	out := make(map[string]string)
+	for _, c := range t.Constraints {
+		if c.Type == core.ConstraintUnique && len(c.Columns) == 1 && len(c.Expressions) == 0 && !c.NullsNotDistinct {
+			out[c.Columns[0]] = c.Name
+		}
+	}
+	return out
+}
+
+// exclusionDefinition renders an EXCLUDE constraint as PostgreSQL spells it.
+func exclusionDefinition(c *core.Constraint) string {
+	elems := make([]string, len(c.Elements))
+	for i, e := range c.Elements {
+		elems[i] = e.String()
+	}
+	using := ""
+	if c.UsingMethod != "" {
+		using = " USING " + c.UsingMethod
+	}
+	return fmt.Sprintf("EXCLUDE%s (%s)", using, strings.Join(elems, ", "))
+}
+
+// uniqueDefinition renders a UNIQUE constraint with its expressions and
+// NULLS NOT DISTINCT flag for comments.
+func uniqueDefinition(c *core.Constraint) string {
+	def := "UNIQUE"
+	if c.NullsNotDistinct {
+		def += " NULLS NOT DISTINCT"
+	}
+	return def + " (" + strings.Join(append(slices.Clone(c.Columns), c.Expressions...), ", ") + ")"
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
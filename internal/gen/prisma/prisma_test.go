@@ -0,0 +1,88 @@
+package prisma
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+	"smf/internal/parser/toml"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func testdataPath(name string) string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..", "..", "test", "data", "gen", name)
+}
+
+func exportShop(t *testing.T) string {
+	t.Helper()
+	p := toml.NewParser()
+	db, err := p.ParseFile(testdataPath("shop.toml"))
+	require.NoError(t, err)
+	require.Empty(t, p.Warnings())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "schema.prisma", files[0].Name)
+	return string(files[0].Content)
+}
+
+func TestExportGolden(t *testing.T) {
+	got := exportShop(t)
+
+	path := testdataPath("shop.prisma.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file; run go test ./internal/gen/prisma -update")
+	assert.Equal(t, string(want), got)
+}
+
+func TestExportUnsupportedFeaturesAsComments(t *testing.T) {
+	got := exportShop(t)
+	assert.Contains(t, got, "// Not supported by Prisma: CONSTRAINT chk_order_items_quantity CHECK (quantity > 0)")
+	assert.Contains(t, got, "// Not supported by Prisma: FULLTEXT INDEX ft_users_display_name (display_name)")
+	assert.Contains(t, got, "// Not supported by Prisma: GENERATED ALWAYS AS (total * 100) STORED")
+}
+
+func TestExportRelations(t *testing.T) {
+	got := exportShop(t)
+	assert.Regexp(t, `user\s+Users\s+@relation\(fields: \[userId\], references: \[id\], onDelete: Cascade, map: "fk_orders_users"\)`, got)
+	assert.Regexp(t, `orders\s+Orders\[\]`, got)
+	// Self-relations are named on both sides.
+	assert.Regexp(t, `parent\s+Categories\?\s+@relation\("fk_categories_categories"`, got)
+	assert.Regexp(t, `categoriesParentId\s+Categories\[\]\s+@relation\("fk_categories_categories"\)`, got)
+}
+
+func TestExportUnsupportedDialect(t *testing.T) {
+	db := &core.Database{Name: "x", Dialect: new(core.DialectOracle)}
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	got := string(files[0].Content)
+	assert.Contains(t, got, `// Dialect "oracle" has no Prisma provider`)
+	assert.NotContains(t, got, "\ndatasource db {")
+}
+
+func TestRegistered(t *testing.T) {
+	e, err := gen.NewExporter("prisma")
+	require.NoError(t, err)
+	assert.IsType(t, &exporter{}, e)
+}
+
+func TestEnumMember(t *testing.T) {
+	assert.Equal(t, "pending", enumMember("pending"))
+	assert.Equal(t, `in_progress @map("in-progress")`, enumMember("in-progress"))
+	assert.Equal(t, `v_1st @map("1st")`, enumMember("1st"))
+	assert.True(t, strings.HasPrefix(enumMember(""), "v_"))
+}
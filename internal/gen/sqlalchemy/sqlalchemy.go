@@ -0,0 +1,569 @@
+// Package sqlalchemy exports a core.Database as SQLAlchemy 2.0 declarative
+// models (models.py). Every table becomes a mapped class; foreign keys become
+// ForeignKey columns plus relationship() hints, and enum columns become
+// Python enum classes.
+package sqlalchemy
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+)
+
+func init() {
+	gen.Register("sqlalchemy", New)
+}
+
+type exporter struct{}
+
+// New returns the SQLAlchemy models exporter.
+func New() gen.SchemaExporter {
+	return &exporter{}
+}
+
+// pythonKeywords may not be used as attribute names.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true,
+	"not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true,
+	// Names that would shadow the declarative machinery.
+	"metadata": true, "registry": true,
+}
+
+// saType describes the SQLAlchemy column type and Python annotation of a
+// portable data type.
+type saType struct {
+	column     string
+	annotation string
+	imports    []string // extra "module" imports needed by the annotation
+}
+
+func columnType(c *core.Column) saType {
+	switch c.Type {
+	case core.DataTypeString:
+		return saType{column: "String", annotation: "str"}
+	case core.DataTypeUUID:
+		return saType{column: "Uuid", annotation: "uuid.UUID", imports: []string{"uuid"}}
+	case core.DataTypeInt:
+		if gen.RawBase(c) == "BIGINT" {
+			return saType{column: "BigInteger", annotation: "int"}
+		}
+		return saType{column: "Integer", annotation: "int"}
+	case core.DataTypeFloat:
+		switch gen.RawBase(c) {
+		case "DECIMAL", "NUMERIC", "NUMBER":
+			return saType{column: "Numeric", annotation: "decimal.Decimal", imports: []string{"decimal"}}
+		}
+		return saType{column: "Float", annotation: "float"}
+	case core.DataTypeBoolean:
+		return saType{column: "Boolean", annotation: "bool"}
+	case core.DataTypeDatetime:
+		return saType{column: "DateTime", annotation: "datetime.datetime", imports: []string{"datetime"}}
+	case core.DataTypeJSON:
+		return saType{column: "JSON", annotation: "Any"}
+	case core.DataTypeBinary:
+		return saType{column: "LargeBinary", annotation: "bytes"}
+	default:
+		return saType{column: "String", annotation: "str"}
+	}
+}
+
+// module collects the pieces of models.py while the classes are rendered.
+type module struct {
+	sa      map[string]bool // names imported from sqlalchemy
+	pg      map[string]bool // names imported from sqlalchemy.dialects.postgresql
+	mysql   map[string]bool // names imported from sqlalchemy.dialects.mysql
+	std     map[string]bool // standard library modules
+	typing  map[string]bool
+	enums   []string
+	shared  map[string]string // database-level enum type -> Python class
+	classes []string
+	dialect core.Dialect
+}
+
+func (e *exporter) Export(db *core.Database) ([]gen.File, error) {
+	m := &module{
+		sa:     map[string]bool{},
+		pg:     map[string]bool{},
+		mysql:  map[string]bool{},
+		shared: map[string]string{},
+		std:    map[string]bool{},
+		typing: map[string]bool{},
+	}
+	if db.Dialect != nil {
+		m.dialect = *db.Dialect
+	}
+
+	tables := slices.Clone(db.Tables)
+	slices.SortFunc(tables, func(a, b *core.Table) int { return strings.Compare(a.Name, b.Name) })
+	for _, t := range tables {
+		m.classes = append(m.classes, m.class(t))
+	}
+
+	return []gen.File{{Name: "models.py", Content: []byte(m.render())}}, nil
+}
+
+func (m *module) render() string {
+	var sb strings.Builder
+	sb.WriteString("# Code generated by smf gen sqlalchemy. DO NOT EDIT.\n\n")
+	sb.WriteString("from __future__ import annotations\n\n")
+
+	std := sortedKeys(m.std)
+	for _, mod := range std {
+		fmt.Fprintf(&sb, "import %s\n", mod)
+	}
+	if typing := sortedKeys(m.typing); len(typing) > 0 {
+		fmt.Fprintf(&sb, "from typing import %s\n", strings.Join(typing, ", "))
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "from sqlalchemy import %s\n", strings.Join(sortedKeys(m.sa), ", "))
+	if mysql := sortedKeys(m.mysql); len(mysql) > 0 {
+		fmt.Fprintf(&sb, "from sqlalchemy.dialects.mysql import %s\n", strings.Join(mysql, ", "))
+	}
+	if pg := sortedKeys(m.pg); len(pg) > 0 {
+		fmt.Fprintf(&sb, "from sqlalchemy.dialects.postgresql import %s\n", strings.Join(pg, ", "))
+	}
+	sb.WriteString("from sqlalchemy.orm import DeclarativeBase, Mapped, mapped_column, relationship\n")
+
+	sb.WriteString("\n\nclass Base(DeclarativeBase):\n    pass\n")
+	for _, e := range m.enums {
+		sb.WriteString("\n\n")
+		sb.WriteString(e)
+	}
+	for _, c := range m.classes {
+		sb.WriteString("\n\n")
+		sb.WriteString(c)
+	}
+	return sb.String()
+}
+
+func (m *module) class(t *core.Table) string {
+	name := className(t.Name)
+	used := map[string]bool{}
+	attrs := make(map[string]string, len(t.Columns))
+	for _, c := range t.Columns {
+		attrs[c.Name] = reserve(used, attrName(c.Name))
+	}
+
+	single := make(map[string]*core.Constraint)
+	var args, comments []string
+	for _, c := range t.Constraints {
+		switch c.Type {
+		case core.ConstraintForeignKey:
+			if len(c.Columns) == 1 {
+				single[c.Columns[0]] = c
+				continue
+			}
+			m.sa["ForeignKeyConstraint"] = true
+			refs := make([]string, len(c.ReferencedColumns))
+			for i, col := range c.ReferencedColumns {
+				refs[i] = c.ReferencedTable + "." + col
+			}
+			args = append(args, fmt.Sprintf("ForeignKeyConstraint(%s, %s%s)", pyList(c.Columns), pyList(refs), fkKwargs(c)))
+		case core.ConstraintUnique:
+			args = append(args, m.unique(c))
+		case core.ConstraintCheck:
+			m.sa["CheckConstraint"] = true
+			args = append(args, fmt.Sprintf("CheckConstraint(%s, name=%s)", pyStr(c.CheckExpression), pyStr(c.Name)))
+		case core.ConstraintExclusion:
+			arg, comment := m.exclusion(t, c)
+			if comment != "" {
+				comments = append(comments, comment)
+				continue
+			}
+			args = append(args, arg)
+		}
+	}
+	for _, idx := range t.Indexes {
+		arg, comment := m.index(idx)
+		if comment != "" {
+			comments = append(comments, comment)
+			continue
+		}
+		args = append(args, arg)
+	}
+	if t.Comment != "" {
+		args = append(args, fmt.Sprintf("{\"comment\": %s}", pyStr(t.Comment)))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s(Base):\n", name)
+	fmt.Fprintf(&sb, "    __tablename__ = %s\n", pyStr(t.Name))
+	for _, c := range comments {
+		fmt.Fprintf(&sb, "    # %s\n", c)
+	}
+	if len(args) > 0 {
+		sb.WriteString("    __table_args__ = (\n")
+		for _, a := range args {
+			fmt.Fprintf(&sb, "        %s,\n", a)
+		}
+		sb.WriteString("    )\n")
+	}
+	sb.WriteString("\n")
+
+	pk := map[string]bool{}
+	if c := t.PrimaryKey(); c != nil {
+		for _, col := range c.Columns {
+			pk[col] = true
+		}
+	}
+	for _, c := range t.Columns {
+		fmt.Fprintf(&sb, "    %s\n", m.column(name, t, c, attrs[c.Name], pk[c.Name], single[c.Name]))
+	}
+
+	var rels []string
+	for _, c := range t.Constraints {
+		if c.Type != core.ConstraintForeignKey {
+			continue
+		}
+		rels = append(rels, m.relationship(name, c, used, attrs))
+	}
+	if len(rels) > 0 {
+		sb.WriteString("\n")
+		for _, r := range rels {
+			fmt.Fprintf(&sb, "    %s\n", r)
+		}
+	}
+	return sb.String()
+}
+
+func (m *module) column(class string, t *core.Table, c *core.Column, attr string, pk bool, fk *core.Constraint) string {
+	typ := columnType(c)
+	for _, mod := range typ.imports {
+		m.std[mod] = true
+	}
+	if typ.annotation == "Any" {
+		m.typing["Any"] = true
+	}
+
+	var args []string
+	if attr != c.Name {
+		args = append(args, pyStr(c.Name))
+	}
+	if c.Type == core.DataTypeEnum && len(c.EnumValues) > 0 {
+		enumName := m.enum(class, c)
+		typ.annotation = enumName
+		m.sa["Enum"] = true
+		dbName := t.Name + "_" + c.Name
+		if c.UserType != "" {
+			dbName = c.UserType
+		}
+		args = append(args, fmt.Sprintf("Enum(%s, name=%s, values_callable=lambda e: [m.value for m in e])",
+			enumName, pyStr(dbName)))
+	} else if c.Type == core.DataTypeSet && len(c.EnumValues) > 0 && isMySQLFamily(m.dialect) {
+		m.mysql["SET"] = true
+		args = append(args, "SET("+pyArgs(c.EnumValues)+")")
+	} else {
+		m.sa[typ.column] = true
+		args = append(args, typ.column+typeParams(typ.column, c))
+	}
+	if fk != nil {
+		m.sa["ForeignKey"] = true
+		args = append(args, fmt.Sprintf("ForeignKey(%s%s)", pyStr(fk.ReferencedTable+"."+fk.ReferencedColumns[0]), fkKwargs(fk)))
+	}
+	if c.IsGenerated {
+		m.sa["Computed"] = true
+		persisted := "False"
+		if c.GenerationStorage == core.GenerationStored {
+			persisted = "True"
+		}
+		args = append(args, fmt.Sprintf("Computed(%s, persisted=%s)", pyStr(c.GenerationExpression), persisted))
+	}
+	if pk {
+		args = append(args, "primary_key=True")
+	}
+	if c.AutoIncrement {
+		args = append(args, "autoincrement=True")
+	}
+	if c.Nullable {
+		args = append(args, "nullable=True")
+	}
+	if d := m.serverDefault(c); d != "" {
+		args = append(args, "server_default="+d)
+	}
+	if c.Comment != "" {
+		args = append(args, "comment="+pyStr(c.Comment))
+	}
+
+	annotation := typ.annotation
+	if c.Nullable {
+		m.typing["Optional"] = true
+		annotation = "Optional[" + annotation + "]"
+	}
+	return fmt.Sprintf("%s: Mapped[%s] = mapped_column(%s)", attr, annotation, strings.Join(args, ", "))
+}
+
+func (m *module) serverDefault(c *core.Column) string {
+	kind, v := gen.ClassifyDefault(c)
+	switch kind {
+	case gen.DefaultNone:
+		return ""
+	case gen.DefaultCurrentTimestamp:
+		m.sa["func"] = true
+		return "func.now()"
+	case gen.DefaultString, gen.DefaultEnum:
+		return pyStr(v)
+	}
+	m.sa["text"] = true
+	return "text(" + pyStr(v) + ")"
+}
+
+// enum renders the Python enum class of an enum column. Columns declared
+// with a database-level enum type share one class named after the type.
+func (m *module) enum(class string, c *core.Column) string {
+	name := class + gen.PascalCase(c.Name)
+	if c.UserType != "" {
+		if shared, ok := m.shared[c.UserType]; ok {
+			return shared
+		}
+		name = className(c.UserType)
+		m.shared[c.UserType] = name
+	}
+	m.std["enum"] = true
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "class %s(enum.Enum):\n", name)
+	used := map[string]bool{}
+	for _, v := range c.EnumValues {
+		member := reserve(used, enumMember(v))
+		fmt.Fprintf(&sb, "    %s = %s\n", member, pyStr(v))
+	}
+	m.enums = append(m.enums, sb.String())
+	return name
+}
+
+// index renders an Index() table argument, or a comment for index kinds the
+// target dialect cannot express through SQLAlchemy keyword arguments.
+func (m *module) index(idx *core.Index) (arg, comment string) {
+	var kwargs []string
+	switch idx.Type {
+	case "", core.IndexTypeBTree:
+	case core.IndexTypeFullText, core.IndexTypeSpatial:
+		if !isMySQLFamily(m.dialect) {
+			return "", fmt.Sprintf("Not supported by SQLAlchemy for %s: %s INDEX %s (%s)", m.dialect, idx.Type, idx.Name, strings.Join(idx.Names(), ", "))
+		}
+		kwargs = append(kwargs, "mysql_prefix="+pyStr(string(idx.Type)))
+	case core.IndexTypeHash, core.IndexTypeGIN, core.IndexTypeGiST:
+		if m.dialect != core.DialectPostgreSQL {
+			return "", fmt.Sprintf("Not supported by SQLAlchemy for %s: %s INDEX %s (%s)", m.dialect, idx.Type, idx.Name, strings.Join(idx.Names(), ", "))
+		}
+		kwargs = append(kwargs, "postgresql_using="+pyStr(strings.ToLower(string(idx.Type))))
+	}
+
+	m.sa["Index"] = true
+	cols := make([]string, len(idx.Columns))
+	lengths := make([]string, 0)
+	for i, c := range idx.Columns {
+		cols[i] = pyStr(c.Name)
+		if c.Order == core.SortDesc {
+			m.sa["text"] = true
+			cols[i] = "text(" + pyStr(c.Name+" DESC") + ")"
+		}
+		if c.Length > 0 {
+			lengths = append(lengths, fmt.Sprintf("%s: %d", pyStr(c.Name), c.Length))
+		}
+	}
+	if len(lengths) > 0 {
+		kwargs = append(kwargs, "mysql_length={"+strings.Join(lengths, ", ")+"}")
+	}
+	if idx.Unique {
+		kwargs = append([]string{"unique=True"}, kwargs...)
+	}
+	parts := append([]string{pyStr(idx.Name)}, cols...)
+	parts = append(parts, kwargs...)
+	return "Index(" + strings.Join(parts, ", ") + ")", ""
+}
+
+// unique renders a UNIQUE constraint. Constraints over expressions become a
+// unique Index, since UniqueConstraint only takes column names.
+func (m *module) unique(c *core.Constraint) string {
+	kwargs := ""
+	if c.NullsNotDistinct {
+		kwargs = ", postgresql_nulls_not_distinct=True"
+	}
+	if len(c.Expressions) == 0 {
+		m.sa["UniqueConstraint"] = true
+		return fmt.Sprintf("UniqueConstraint(%s, name=%s%s)", pyArgs(c.Columns), pyStr(c.Name), kwargs)
+	}
+	m.sa["Index"] = true
+	m.sa["text"] = true
+	parts := []string{pyStr(c.Name)}
+	for _, col := range c.Columns {
+		parts = append(parts, pyStr(col))
+	}
+	for _, expr := range c.Expressions {
+		parts = append(parts, "text("+pyStr(expr)+")")
+	}
+	return fmt.Sprintf("Index(%s, unique=True%s)", strings.Join(parts, ", "), kwargs)
+}
+
+// exclusion renders an EXCLUDE constraint as a PostgreSQL ExcludeConstraint,
+// or a comment for other dialects. Elements naming a plain column are passed
+// by name; anything else is wrapped in text().
+func (m *module) exclusion(t *core.Table, c *core.Constraint) (arg, comment string) {
+	elems := make([]string, len(c.Elements))
+	for i, e := range c.Elements {
+		elems[i] = e.String()
+	}
+	if m.dialect != core.DialectPostgreSQL {
+		return "", fmt.Sprintf("Not supported by SQLAlchemy for %s: CONSTRAINT %s EXCLUDE (%s)", m.dialect, c.Name, strings.Join(elems, ", "))
+	}
+
+	m.pg["ExcludeConstraint"] = true
+	parts := make([]string, 0, len(c.Elements)+2)
+	for _, e := range c.Elements {
+		expr := pyStr(e.Expression)
+		if t.FindColumn(e.Expression) == nil {
+			m.sa["text"] = true
+			expr = "text(" + expr + ")"
+		}
+		parts = append(parts, fmt.Sprintf("(%s, %s)", expr, pyStr(e.Operator)))
+	}
+	if c.UsingMethod != "" {
+		parts = append(parts, "using="+pyStr(c.UsingMethod))
+	}
+	parts = append(parts, "name="+pyStr(c.Name))
+	return "ExcludeConstraint(" + strings.Join(parts, ", ") + ")", ""
+}
+
+// relationship renders a relationship() hint for a foreign key. It names the
+// local columns explicitly so several relations to one target work, and
+// leaves back_populates to the user.
+func (m *module) relationship(class string, fk *core.Constraint, used map[string]bool, attrs map[string]string) string {
+	target := className(fk.ReferencedTable)
+	name := target
+	if len(fk.Columns) == 1 {
+		if base, ok := strings.CutSuffix(fk.Columns[0], "_id"); ok && base != "" {
+			name = base
+		}
+	}
+	name = reserve(used, attrName(strings.ToLower(name)))
+
+	cols := make([]string, len(fk.Columns))
+	for i, c := range fk.Columns {
+		cols[i] = attrs[c]
+	}
+	foreignKeys := "[" + strings.Join(cols, ", ") + "]"
+	remote := ""
+	if target == class {
+		// A self-reference needs the remote side spelled out; the referenced
+		// columns are attributes of the class body being defined.
+		refs := make([]string, len(fk.ReferencedColumns))
+		for i, c := range fk.ReferencedColumns {
+			refs[i] = attrs[c]
+		}
+		remote = ", remote_side=[" + strings.Join(refs, ", ") + "]"
+	}
+	return fmt.Sprintf("%s: Mapped[%s] = relationship(foreign_keys=%s%s)", name, pyStr(target), foreignKeys, remote)
+}
+
+// typeParams renders the length or precision of String and Numeric columns,
+// which MySQL needs to emit VARCHAR and DECIMAL.
+func typeParams(column string, c *core.Column) string {
+	params := gen.RawParams(c)
+	switch {
+	case column == "String" && len(params) == 1:
+		return fmt.Sprintf("(%d)", params[0])
+	case column == "Numeric" && len(params) == 1:
+		return fmt.Sprintf("(%d)", params[0])
+	case column == "Numeric" && len(params) == 2:
+		return fmt.Sprintf("(%d, %d)", params[0], params[1])
+	}
+	return ""
+}
+
+func fkKwargs(fk *core.Constraint) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, ", name=%s", pyStr(fk.Name))
+	if fk.OnDelete != core.RefActionNone {
+		fmt.Fprintf(&sb, ", ondelete=%s", pyStr(string(fk.OnDelete)))
+	}
+	if fk.OnUpdate != core.RefActionNone {
+		fmt.Fprintf(&sb, ", onupdate=%s", pyStr(string(fk.OnUpdate)))
+	}
+	return sb.String()
+}
+
+func isMySQLFamily(d core.Dialect) bool {
+	return d == core.DialectMySQL || d == core.DialectMariaDB || d == core.DialectTiDB
+}
+
+func className(table string) string {
+	name := gen.PascalCase(table)
+	if name == "" || !isIdentStart(name[0]) {
+		name = "T" + name
+	}
+	return name
+}
+
+func attrName(column string) string {
+	name := strings.Join(gen.SplitWords(column), "_")
+	if name == "" || !isIdentStart(name[0]) {
+		name = "c_" + name
+	}
+	if pythonKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+func enumMember(v string) string {
+	name := strings.ToLower(strings.Join(gen.SplitWords(v), "_"))
+	if name == "" || !isIdentStart(name[0]) {
+		name = "v_" + name
+	}
+	if pythonKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+func isIdentStart(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func reserve(used map[string]bool, name string) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = name + "_" + strconv.Itoa(i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func pyStr(s string) string {
+	return strconv.Quote(s)
+}
+
+func pyList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, s := range items {
+		quoted[i] = pyStr(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func pyArgs(items []string) string {
+	quoted := make([]string, len(items))
+	for i, s := range items {
+		quoted[i] = pyStr(s)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
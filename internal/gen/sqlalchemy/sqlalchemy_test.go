@@ -0,0 +1,196 @@
+package sqlalchemy
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+	"smf/internal/gen"
+	"smf/internal/parser/toml"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func testdataPath(name string) string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(filename), "..", "..", "..", "test", "data", "gen", name)
+}
+
+func exportShop(t *testing.T) string {
+	t.Helper()
+	p := toml.NewParser()
+	db, err := p.ParseFile(testdataPath("shop.toml"))
+	require.NoError(t, err)
+	require.Empty(t, p.Warnings())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "models.py", files[0].Name)
+	return string(files[0].Content)
+}
+
+func TestExportGolden(t *testing.T) {
+	got := exportShop(t)
+
+	path := testdataPath("shop.py.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file; run go test ./internal/gen/sqlalchemy -update")
+	assert.Equal(t, string(want), got)
+}
+
+func TestExportRelationships(t *testing.T) {
+	got := exportShop(t)
+	assert.Contains(t, got, `user: Mapped["Users"] = relationship(foreign_keys=[user_id])`)
+	assert.Contains(t, got, `parent: Mapped["Categories"] = relationship(foreign_keys=[parent_id], remote_side=[id])`)
+}
+
+func TestExportPythonKeywordColumn(t *testing.T) {
+	db := &core.Database{
+		Name:    "app",
+		Dialect: new(core.DialectPostgreSQL),
+		Tables: []*core.Table{{
+			Name: "events",
+			Columns: []*core.Column{
+				{Name: "id", Type: core.DataTypeInt, RawType: "INT", PrimaryKey: true},
+				{Name: "from", Type: core.DataTypeString, RawType: "VARCHAR(32)"},
+				{Name: "kind", Type: core.DataTypeEnum, RawType: "ENUM('class','2fa')", EnumValues: []string{"class", "2fa"}},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	got := string(files[0].Content)
+	assert.Contains(t, got, `from_: Mapped[str] = mapped_column("from", String(32))`)
+	assert.Contains(t, got, "    class_ = \"class\"\n")
+	assert.Contains(t, got, "    v_2fa = \"2fa\"\n")
+}
+
+func TestRegistered(t *testing.T) {
+	e, err := gen.NewExporter("sqlalchemy")
+	require.NoError(t, err)
+	assert.IsType(t, &exporter{}, e)
+	assert.True(t, strings.Contains(strings.Join(gen.Exporters(), ","), "sqlalchemy"))
+}
+
+func TestExportUniqueExpressions(t *testing.T) {
+	db := &core.Database{
+		Name:    "app",
+		Dialect: new(core.DialectPostgreSQL),
+		Tables: []*core.Table{{
+			Name: "users",
+			Columns: []*core.Column{
+				{Name: "id", Type: core.DataTypeInt, PrimaryKey: true},
+				{Name: "tenant_id", Type: core.DataTypeInt, Nullable: true},
+				{Name: "email", Type: core.DataTypeString},
+			},
+			Constraints: []*core.Constraint{
+				{Name: "uq_users_email_ci", Type: core.ConstraintUnique, Columns: []string{"tenant_id"}, Expressions: []string{"lower(email)"}},
+				{Name: "uq_users_tenant", Type: core.ConstraintUnique, Columns: []string{"tenant_id"}, NullsNotDistinct: true},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	got := string(files[0].Content)
+	assert.Contains(t, got, `Index("uq_users_email_ci", "tenant_id", text("lower(email)"), unique=True),`)
+	assert.Contains(t, got, `UniqueConstraint("tenant_id", name="uq_users_tenant", postgresql_nulls_not_distinct=True),`)
+}
+
+func TestExportExclusionConstraint(t *testing.T) {
+	db := &core.Database{
+		Name:    "app",
+		Dialect: new(core.DialectPostgreSQL),
+		Tables: []*core.Table{{
+			Name: "bookings",
+			Columns: []*core.Column{
+				{Name: "id", Type: core.DataTypeInt, PrimaryKey: true},
+				{Name: "room_id", Type: core.DataTypeInt},
+				{Name: "starts_at", Type: core.DataTypeDatetime},
+				{Name: "ends_at", Type: core.DataTypeDatetime},
+			},
+			Constraints: []*core.Constraint{{
+				Name:        "ex_bookings_room_during",
+				Type:        core.ConstraintExclusion,
+				UsingMethod: "gist",
+				Elements: []core.ExclusionElement{
+					{Expression: "room_id", Operator: "="},
+					{Expression: "tstzrange(starts_at, ends_at)", Operator: "&&"},
+				},
+			}},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	got := string(files[0].Content)
+	assert.Contains(t, got, "from sqlalchemy.dialects.postgresql import ExcludeConstraint\n")
+	assert.Contains(t, got, `ExcludeConstraint(("room_id", "="), (text("tstzrange(starts_at, ends_at)"), "&&"), using="gist", name="ex_bookings_room_during"),`)
+
+	db.Dialect = new(core.DialectMySQL)
+	files, err = New().Export(db)
+	require.NoError(t, err)
+	got = string(files[0].Content)
+	assert.NotContains(t, got, "ExcludeConstraint")
+	assert.Contains(t, got, "# Not supported by SQLAlchemy for mysql: CONSTRAINT ex_bookings_room_during EXCLUDE (room_id WITH =, tstzrange(starts_at, ends_at) WITH &&)")
+}
+
+func TestExportSharedEnumType(t *testing.T) {
+	db := &core.Database{
+		Name:      "app",
+		Dialect:   new(core.DialectPostgreSQL),
+		EnumTypes: []*core.EnumType{{Name: "mood", Values: []string{"sad", "happy"}}},
+		Tables: []*core.Table{{
+			Name: "people",
+			Columns: []*core.Column{
+				{Name: "id", Type: core.DataTypeInt, PrimaryKey: true},
+				{Name: "current_mood", UserType: "mood"},
+				{Name: "usual_mood", UserType: "mood"},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	got := string(files[0].Content)
+	assert.Equal(t, 1, strings.Count(got, "class Mood(enum.Enum):"))
+	assert.Contains(t, got, `current_mood: Mapped[Mood] = mapped_column(Enum(Mood, name="mood", values_callable=lambda e: [m.value for m in e]))`)
+	assert.Contains(t, got, `usual_mood: Mapped[Mood] = mapped_column(Enum(Mood, name="mood", values_callable=lambda e: [m.value for m in e]))`)
+}
+
+func TestExportSetColumn(t *testing.T) {
+	db := &core.Database{
+		Name:    "app",
+		Dialect: new(core.DialectMySQL),
+		Tables: []*core.Table{{
+			Name: "users",
+			Columns: []*core.Column{
+				{Name: "id", Type: core.DataTypeInt, PrimaryKey: true},
+				{Name: "perms", Type: core.DataTypeSet, EnumValues: []string{"read", "write"}},
+			},
+		}},
+	}
+	require.NoError(t, db.Validate())
+
+	files, err := New().Export(db)
+	require.NoError(t, err)
+	got := string(files[0].Content)
+	assert.Contains(t, got, "from sqlalchemy.dialects.mysql import SET\n")
+	assert.Contains(t, got, `perms: Mapped[str] = mapped_column(SET("read", "write"))`)
+}
@@ -4,10 +4,13 @@
 package schema
 
 import (
+	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 
 	"smf/internal/core"
+	"smf/internal/parser/prisma"
 	"smf/internal/parser/toml"
 )
 
@@ -15,21 +18,59 @@ type Parser interface {
 	Parse(r io.Reader) (*core.Database, error)
 }
 
-func ParseFile(path string) (*core.Database, error) {
-	ext := filepath.Ext(path)
+// Source formats accepted by ParseFileFormat.
+const (
+	FormatTOML   = "toml"
+	FormatPrisma = "prisma"
+)
+
+// Formats lists the supported source formats.
+func Formats() []string {
+	return []string{FormatTOML, FormatPrisma}
+}
+
+// ParseFile parses the schema at path, selecting the format by extension.
+func ParseFile(path string) (*core.Database, []core.Warning, error) {
+	return ParseFileFormat(path, "")
+}
+
+// ParseFileFormat parses the schema at path in the given format. An empty
+// format selects it by the file extension. The warnings are the non-fatal
+// findings of the format's parser, such as unknown keys, constructs that
+// were not imported and lint findings.
+func ParseFileFormat(path, format string) (*core.Database, []core.Warning, error) {
+	explicit := format
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
 
-	switch ext {
-	case ".toml":
-		return toml.NewParser().ParseFile(path)
+	switch format {
+	case FormatTOML:
+		p := toml.NewParser()
+		db, err := p.ParseFile(path)
+		return db, p.Warnings(), err
+	case FormatPrisma:
+		p := prisma.NewParser()
+		db, err := p.ParseFile(path)
+		return db, p.Warnings(), err
 	default:
-		return nil, &UnsupportedFormatError{Path: path}
+		return nil, nil, &UnsupportedFormatError{Path: path, Format: explicit}
 	}
 }
 
+// UnsupportedFormatError is returned for a schema file whose format is not
+// supported.
 type UnsupportedFormatError struct {
 	Path string
+	// Format is the format that was asked for; empty when it was detected
+	// from the file extension.
+	Format string
 }
 
 func (e *UnsupportedFormatError) Error() string {
-	return "unsupported file format: " + e.Path
+	supported := strings.Join(Formats(), ", ")
+	if e.Format != "" {
+		return fmt.Sprintf("unsupported format %q; supported formats: %s", e.Format, supported)
+	}
+	return fmt.Sprintf("unsupported file format: %s; use a .toml or .prisma file, or set --from-format (%s)", e.Path, supported)
 }
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+func TestParseFileFormatWarnings(t *testing.T) {
+	db, warnings, err := ParseFile("../../test/data/prisma/blog.prisma")
+	require.NoError(t, err)
+	require.NotNil(t, db)
+	assert.True(t, containsCode(warnings, core.WarningUnsupportedFeature), "prisma warnings are returned")
+
+	path := filepath.Join(t.TempDir(), "schema.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[database]
+name    = "app"
+dialect = "mysql"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+  nullabe     = true
+`), 0o644))
+	_, warnings, err = ParseFileFormat(path, FormatTOML)
+	require.NoError(t, err)
+	assert.True(t, containsCode(warnings, core.WarningUnknownKey), "toml warnings are returned")
+}
+
+func TestParseFileFormatUnsupported(t *testing.T) {
+	_, _, err := ParseFileFormat("schema.toml", "yaml")
+	var ufe *UnsupportedFormatError
+	require.ErrorAs(t, err, &ufe)
+	assert.Equal(t, `unsupported format "yaml"; supported formats: toml, prisma`, err.Error())
+
+	_, _, err = ParseFile("schema.sql")
+	require.ErrorAs(t, err, &ufe)
+	assert.Equal(t, "unsupported file format: schema.sql; use a .toml or .prisma file, or set --from-format (toml, prisma)", err.Error())
+}
+
+func containsCode(warnings []core.Warning, code core.WarningCode) bool {
+	for _, w := range warnings {
+		if w.Code == code {
+			return true
+		}
+	}
+	return false
+}
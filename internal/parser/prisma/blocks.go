@@ -0,0 +1,103 @@
+package prisma
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// indexTypes maps the type: argument of @@index.
+var indexTypes = map[string]core.IndexType{
+	"BTree": core.IndexTypeBTree,
+	"Hash":  core.IndexTypeHash,
+	"Gin":   core.IndexTypeGIN,
+	"Gist":  core.IndexTypeGiST,
+}
+
+// blockAttribute applies a model-level @@ attribute to the table.
+func (c *converter) blockAttribute(m *block, t *core.Table, a *attribute) error {
+	switch a.name {
+	case "map", "ignore":
+		return nil
+	case "id":
+		cols, err := c.attributeColumns(m, a)
+		if err != nil {
+			return err
+		}
+		t.Constraints = append(t.Constraints, &core.Constraint{
+			Name:    mapName(a, t.Name+"_pkey"),
+			Type:    core.ConstraintPrimaryKey,
+			Columns: cols,
+		})
+	case "unique":
+		cols, err := c.attributeColumns(m, a)
+		if err != nil {
+			return err
+		}
+		t.Constraints = append(t.Constraints, &core.Constraint{
+			Name:    mapName(a, t.Name+"_"+strings.Join(cols, "_")+"_key"),
+			Type:    core.ConstraintUnique,
+			Columns: cols,
+		})
+	case "index", "fulltext":
+		idx, err := c.index(m, t, a)
+		if err != nil {
+			return err
+		}
+		t.Indexes = append(t.Indexes, idx)
+	default:
+		c.warn(m.name, "", "unsupported attribute %q", "@@"+a.name)
+	}
+	return nil
+}
+
+func (c *converter) attributeColumns(m *block, a *attribute) ([]string, error) {
+	v, ok := a.arg("fields", 0)
+	if !ok || v.kind != valList || len(v.items) == 0 {
+		return nil, &ParseError{Line: a.line, Err: fmt.Errorf("@@%s needs a list of fields", a.name)}
+	}
+	return c.columnList(m.name, v), nil
+}
+
+func (c *converter) index(m *block, t *core.Table, a *attribute) (*core.Index, error) {
+	v, ok := a.arg("fields", 0)
+	if !ok || v.kind != valList || len(v.items) == 0 {
+		return nil, &ParseError{Line: a.line, Err: fmt.Errorf("@@%s needs a list of fields", a.name)}
+	}
+	idx := &core.Index{}
+	names := make([]string, 0, len(v.items))
+	for _, item := range v.items {
+		ic := core.ColumnIndex{Name: c.columnName(m.name, item.text)}
+		if sort, ok := findArg(item.args, "sort", -1); ok && sort.text == "Desc" {
+			ic.Order = core.SortDesc
+		}
+		if length, ok := findArg(item.args, "length", -1); ok {
+			n, err := strconv.Atoi(length.text)
+			if err != nil {
+				return nil, &ParseError{Line: a.line, Err: fmt.Errorf("@@%s: invalid length %q", a.name, length.text)}
+			}
+			ic.Length = n
+		}
+		if _, ok := findArg(item.args, "ops", -1); ok {
+			c.warn(m.name, "", "operator classes on @@%s are not supported", a.name)
+		}
+		idx.Columns = append(idx.Columns, ic)
+		names = append(names, ic.Name)
+	}
+	idx.Name = mapName(a, t.Name+"_"+strings.Join(names, "_")+"_idx")
+
+	if a.name == "fulltext" {
+		idx.Type = core.IndexTypeFullText
+		return idx, nil
+	}
+	if typ, ok := a.arg("type", -1); ok {
+		it, ok := indexTypes[typ.text]
+		if !ok {
+			c.warn(m.name, "", "index type %s on @@index is not supported", typ.text)
+		}
+		idx.Type = it
+	}
+	return idx, nil
+}
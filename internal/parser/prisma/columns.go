@@ -0,0 +1,260 @@
+package prisma
+
+import (
+	"fmt"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// scalarTypes maps Prisma scalar types to portable types and, where the
+// portable type alone would lose information, a default raw type.
+var scalarTypes = map[string]struct {
+	typ core.DataType
+	raw string
+}{
+	"String":   {typ: core.DataTypeString},
+	"Boolean":  {typ: core.DataTypeBoolean},
+	"Int":      {typ: core.DataTypeInt},
+	"BigInt":   {typ: core.DataTypeInt, raw: "BIGINT"},
+	"Float":    {typ: core.DataTypeFloat},
+	"Decimal":  {typ: core.DataTypeFloat, raw: "DECIMAL"},
+	"DateTime": {typ: core.DataTypeDatetime},
+	"Json":     {typ: core.DataTypeJSON},
+	"Bytes":    {typ: core.DataTypeBinary},
+}
+
+// referentialActions maps Prisma onDelete/onUpdate values.
+var referentialActions = map[string]core.ReferentialAction{
+	"Cascade":    core.RefActionCascade,
+	"Restrict":   core.RefActionRestrict,
+	"NoAction":   core.RefActionNoAction,
+	"SetNull":    core.RefActionSetNull,
+	"SetDefault": core.RefActionSetDefault,
+}
+
+// clientDefaults are @default functions evaluated by Prisma Client rather
+// than the database.
+var clientDefaults = map[string]bool{
+	"uuid": true, "cuid": true, "ulid": true, "nanoid": true,
+}
+
+func (c *converter) field(m *block, t *core.Table, f *field) error {
+	if f.attr("ignore") != nil {
+		c.warn(m.name, f.name, "field is marked @ignore and was not imported")
+		return nil
+	}
+	if _, ok := c.models[f.typ]; ok {
+		return c.relation(m, t, f)
+	}
+	if f.list {
+		c.warn(m.name, f.name, "scalar list type %s[] is not supported", f.typ)
+		return nil
+	}
+
+	col := &core.Column{
+		Name:     c.columnName(m.name, f.name),
+		Nullable: f.optional,
+		Comment:  f.doc,
+	}
+	switch {
+	case f.typ == "Unsupported":
+		v, ok := findArg(f.typeArgs, "", 0)
+		if !ok || v.kind != valString {
+			return &ParseError{Line: f.line, Err: fmt.Errorf("field %q: Unsupported() needs the database type", f.name)}
+		}
+		col.RawType = v.text
+		col.Type = core.NormalizeDataType(v.text)
+	case c.enums[f.typ] != nil:
+		col.Type = core.DataTypeEnum
+		col.EnumValues = c.enumValues(f.typ)
+	default:
+		st, ok := scalarTypes[f.typ]
+		if !ok {
+			return &ParseError{Line: f.line, Err: fmt.Errorf("field %q: unknown type %q", f.name, f.typ)}
+		}
+		col.Type, col.RawType = st.typ, st.raw
+	}
+
+	for _, a := range f.attrs {
+		switch {
+		case a.name == "id":
+			t.Constraints = append(t.Constraints, &core.Constraint{
+				Name:    mapName(a, t.Name+"_pkey"),
+				Type:    core.ConstraintPrimaryKey,
+				Columns: []string{col.Name},
+			})
+		case a.name == "unique":
+			t.Constraints = append(t.Constraints, &core.Constraint{
+				Name:    mapName(a, t.Name+"_"+col.Name+"_key"),
+				Type:    core.ConstraintUnique,
+				Columns: []string{col.Name},
+			})
+		case a.name == "default":
+			c.fieldDefault(m, f, col, a)
+		case a.name == "map":
+		case strings.HasPrefix(a.name, "db."):
+			col.RawType = nativeType(a)
+			if a.name == "db.Uuid" || a.name == "db.UniqueIdentifier" {
+				col.Type = core.DataTypeUUID
+			}
+		case a.name == "updatedAt":
+			c.warn(m.name, f.name, "@updatedAt is maintained by Prisma Client and has no database equivalent")
+		default:
+			c.warn(m.name, f.name, "unsupported attribute %q", "@"+a.name)
+		}
+	}
+	t.Columns = append(t.Columns, col)
+	return nil
+}
+
+func (c *converter) fieldDefault(m *block, f *field, col *core.Column, a *attribute) {
+	v, ok := a.arg("value", 0)
+	if !ok {
+		return
+	}
+	switch v.kind {
+	case valString, valNumber:
+		col.DefaultValue = new(v.text)
+	case valIdent:
+		switch v.text {
+		case "true":
+			col.DefaultValue = new("TRUE")
+		case "false":
+			col.DefaultValue = new("FALSE")
+		default:
+			col.DefaultValue = new(c.enumDBValue(f.typ, v.text))
+		}
+	case valCall:
+		switch {
+		case v.text == "autoincrement":
+			col.AutoIncrement = true
+		case v.text == "now":
+			col.DefaultValue = new("CURRENT_TIMESTAMP")
+		case v.text == "dbgenerated":
+			if expr, ok := findArg(v.args, "", 0); ok && expr.kind == valString {
+				col.DefaultValue = new(expr.text)
+			}
+		case clientDefaults[v.text]:
+			c.warn(m.name, f.name, "@default(%s()) is generated by Prisma Client; no database default was imported", v.text)
+		default:
+			c.warn(m.name, f.name, "unsupported default function %s()", v.text)
+		}
+	case valList:
+		c.warn(m.name, f.name, "list defaults are not supported")
+	}
+}
+
+// relation turns a relation field with fields/references into a foreign key
+// on the model's table. The other side of a relation (no fields argument) has
+// no database representation.
+func (c *converter) relation(m *block, t *core.Table, f *field) error {
+	a := f.attr("relation")
+	for _, other := range f.attrs {
+		if other.name != "relation" {
+			c.warn(m.name, f.name, "unsupported attribute %q", "@"+other.name)
+		}
+	}
+	if a == nil {
+		return nil
+	}
+	fields, ok := a.arg("fields", -1)
+	if !ok {
+		return nil
+	}
+	refs, ok := a.arg("references", -1)
+	if !ok {
+		return &ParseError{Line: f.line, Err: fmt.Errorf("field %q: @relation has fields but no references", f.name)}
+	}
+	target := c.models[f.typ]
+	fk := &core.Constraint{
+		Type:              core.ConstraintForeignKey,
+		ReferencedTable:   tableName(target),
+		Columns:           c.columnList(m.name, fields),
+		ReferencedColumns: c.columnList(f.typ, refs),
+	}
+	fk.Name = mapName(a, t.Name+"_"+strings.Join(fk.Columns, "_")+"_fkey")
+	for key, dst := range map[string]*core.ReferentialAction{"onDelete": &fk.OnDelete, "onUpdate": &fk.OnUpdate} {
+		v, ok := a.arg(key, -1)
+		if !ok {
+			continue
+		}
+		action, ok := referentialActions[v.text]
+		if !ok {
+			return &ParseError{Line: f.line, Err: fmt.Errorf("field %q: unknown referential action %q", f.name, v.text)}
+		}
+		*dst = action
+	}
+	t.Constraints = append(t.Constraints, fk)
+	return nil
+}
+
+// enumValues returns the database values of an enum, honoring @map.
+func (c *converter) enumValues(name string) []string {
+	b := c.enums[name]
+	values := make([]string, 0, len(b.fields))
+	for _, f := range b.fields {
+		values = append(values, c.enumDBValue(name, f.name))
+	}
+	return values
+}
+
+func (c *converter) enumDBValue(enum, member string) string {
+	b, ok := c.enums[enum]
+	if !ok {
+		return member
+	}
+	for _, f := range b.fields {
+		if f.name == member {
+			if a := f.attr("map"); a != nil {
+				if v, ok := a.arg("name", 0); ok && v.kind == valString {
+					return v.text
+				}
+			}
+		}
+	}
+	return member
+}
+
+// columnList resolves a list of field references to column names.
+func (c *converter) columnList(model string, v value) []string {
+	items := v.items
+	if v.kind != valList {
+		items = []value{v}
+	}
+	cols := make([]string, 0, len(items))
+	for _, item := range items {
+		cols = append(cols, c.columnName(model, item.text))
+	}
+	return cols
+}
+
+// mapName returns the map: argument of a constraint attribute, or def.
+func mapName(a *attribute, def string) string {
+	if v, ok := a.arg("map", -1); ok && v.kind == valString {
+		return v.text
+	}
+	return def
+}
+
+// nativeType renders a @db.* attribute as a SQL type, e.g. @db.VarChar(255)
+// as VARCHAR(255) and @db.UnsignedInt as INT UNSIGNED.
+func nativeType(a *attribute) string {
+	name := strings.TrimPrefix(a.name, "db.")
+	suffix := ""
+	if base, ok := strings.CutPrefix(name, "Unsigned"); ok {
+		name, suffix = base, " UNSIGNED"
+	}
+	sql := strings.ToUpper(name)
+	if name == "DoublePrecision" {
+		sql = "DOUBLE PRECISION"
+	}
+	if len(a.args) > 0 {
+		params := make([]string, len(a.args))
+		for i, ar := range a.args {
+			params[i] = ar.val.text
+		}
+		sql += "(" + strings.Join(params, ",") + ")"
+	}
+	return sql + suffix
+}
@@ -0,0 +1,39 @@
+package prisma
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is returned when a Prisma schema cannot be parsed. It carries the
+// source line of the failure.
+type ParseError struct {
+	// File is the path of the schema file; empty when parsing from a reader.
+	File string
+	// Line is the 1-based line number of the failure (0 when unknown).
+	Line int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	var loc []string
+	if e.File != "" {
+		loc = append(loc, e.File)
+	}
+	if e.Line > 0 {
+		if e.File != "" {
+			loc = append(loc, fmt.Sprint(e.Line))
+		} else {
+			loc = append(loc, fmt.Sprintf("line %d", e.Line))
+		}
+	}
+	if len(loc) == 0 {
+		return "prisma: " + e.Err.Error()
+	}
+	return "prisma: " + strings.Join(loc, ":") + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,379 @@
+package prisma
+
+import (
+	"fmt"
+	"strings"
+)
+
+// block is a top-level declaration such as `model User { ... }`.
+type block struct {
+	keyword string // model, enum, datasource, generator, view, type
+	name    string
+	line    int
+	doc     string
+	fields  []*field     // model/view/type fields and enum values
+	attrs   []*attribute // block attributes (@@...)
+	props   map[string]value
+}
+
+// field is a model field or an enum value. Enum values have no type.
+type field struct {
+	name     string
+	typ      string
+	typeArgs []arg // Unsupported("...")
+	optional bool
+	list     bool
+	attrs    []*attribute
+	doc      string
+	line     int
+}
+
+type attribute struct {
+	name string // "id", "db.VarChar", "relation", ...
+	args []arg
+	line int
+}
+
+type arg struct {
+	name string // empty for positional arguments
+	val  value
+}
+
+type valueKind int
+
+const (
+	valString valueKind = iota
+	valNumber
+	valIdent // bare identifier: true, Cascade, an enum value
+	valCall  // function call or identifier with arguments: now(), email(sort: Desc)
+	valList
+)
+
+type value struct {
+	kind  valueKind
+	text  string
+	args  []arg
+	items []value
+}
+
+// arg returns the named argument, falling back to the positional argument at
+// index pos when pos >= 0.
+func (a *attribute) arg(name string, pos int) (value, bool) {
+	return findArg(a.args, name, pos)
+}
+
+func findArg(args []arg, name string, pos int) (value, bool) {
+	n := 0
+	for _, ar := range args {
+		if ar.name == name {
+			return ar.val, true
+		}
+		if ar.name == "" {
+			if n == pos {
+				return ar.val, true
+			}
+			n++
+		}
+	}
+	return value{}, false
+}
+
+func (f *field) attr(name string) *attribute {
+	for _, a := range f.attrs {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func (b *block) attr(name string) *attribute {
+	for _, a := range b.attrs {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+type grammar struct {
+	toks []token
+	pos  int
+}
+
+// parseBlocks turns the token stream into top-level blocks.
+func parseBlocks(toks []token) ([]*block, error) {
+	g := &grammar{toks: toks}
+	var blocks []*block
+	var doc []string
+	for {
+		t := g.peek()
+		switch t.kind {
+		case tokEOF:
+			return blocks, nil
+		case tokNewline:
+			g.next()
+			continue
+		case tokDoc:
+			doc = append(doc, g.next().text)
+			continue
+		}
+		b, err := g.block()
+		if err != nil {
+			return nil, err
+		}
+		b.doc = strings.Join(doc, "\n")
+		doc = nil
+		blocks = append(blocks, b)
+	}
+}
+
+func (g *grammar) peek() token { return g.toks[g.pos] }
+
+func (g *grammar) next() token {
+	t := g.toks[g.pos]
+	if t.kind != tokEOF {
+		g.pos++
+	}
+	return t
+}
+
+func (g *grammar) errorf(t token, format string, args ...any) error {
+	return &ParseError{Line: t.line, Err: fmt.Errorf(format, args...)}
+}
+
+func (g *grammar) expect(text string) error {
+	t := g.next()
+	if t.kind != tokPunct || t.text != text {
+		return g.errorf(t, "expected %q, found %s", text, t)
+	}
+	return nil
+}
+
+func (g *grammar) ident() (token, error) {
+	t := g.next()
+	if t.kind != tokIdent {
+		return t, g.errorf(t, "expected identifier, found %s", t)
+	}
+	return t, nil
+}
+
+func (g *grammar) isPunct(text string) bool {
+	t := g.peek()
+	return t.kind == tokPunct && t.text == text
+}
+
+func (g *grammar) block() (*block, error) {
+	kw, err := g.ident()
+	if err != nil {
+		return nil, err
+	}
+	switch kw.text {
+	case "model", "enum", "datasource", "generator", "view", "type":
+	default:
+		return nil, g.errorf(kw, "unknown block %q", kw.text)
+	}
+	name, err := g.ident()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.expect("{"); err != nil {
+		return nil, err
+	}
+	b := &block{keyword: kw.text, name: name.text, line: kw.line, props: map[string]value{}}
+
+	var doc []string
+	for {
+		t := g.peek()
+		switch {
+		case t.kind == tokNewline:
+			g.next()
+			continue
+		case t.kind == tokDoc:
+			doc = append(doc, g.next().text)
+			continue
+		case t.kind == tokEOF:
+			return nil, g.errorf(t, "%s %q is not closed", b.keyword, b.name)
+		case t.kind == tokPunct && t.text == "}":
+			g.next()
+			return b, nil
+		case t.kind == tokPunct && t.text == "@@":
+			a, err := g.attribute()
+			if err != nil {
+				return nil, err
+			}
+			b.attrs = append(b.attrs, a)
+		case b.keyword == "datasource" || b.keyword == "generator":
+			key, err := g.ident()
+			if err != nil {
+				return nil, err
+			}
+			if err := g.expect("="); err != nil {
+				return nil, err
+			}
+			v, err := g.value()
+			if err != nil {
+				return nil, err
+			}
+			b.props[key.text] = v
+		default:
+			f, err := g.field(b.keyword == "enum")
+			if err != nil {
+				return nil, err
+			}
+			f.doc = strings.Join(doc, "\n")
+			b.fields = append(b.fields, f)
+		}
+		doc = nil
+		if err := g.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// endOfLine consumes the line break (or closing brace) after a member.
+func (g *grammar) endOfLine() error {
+	t := g.peek()
+	switch {
+	case t.kind == tokNewline:
+		g.next()
+		return nil
+	case t.kind == tokDoc:
+		return nil
+	case t.kind == tokPunct && t.text == "}":
+		return nil
+	}
+	return g.errorf(t, "unexpected %s", t)
+}
+
+func (g *grammar) field(enumValue bool) (*field, error) {
+	name, err := g.ident()
+	if err != nil {
+		return nil, err
+	}
+	f := &field{name: name.text, line: name.line}
+	if !enumValue {
+		typ, err := g.ident()
+		if err != nil {
+			return nil, err
+		}
+		f.typ = typ.text
+		if g.isPunct("(") {
+			if f.typeArgs, err = g.args(); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case g.isPunct("?"):
+			g.next()
+			f.optional = true
+		case g.isPunct("["):
+			g.next()
+			if err := g.expect("]"); err != nil {
+				return nil, err
+			}
+			f.list = true
+		}
+	}
+	for g.isPunct("@") {
+		a, err := g.attribute()
+		if err != nil {
+			return nil, err
+		}
+		f.attrs = append(f.attrs, a)
+	}
+	return f, nil
+}
+
+// attribute parses `@name(args)` or `@@name(args)`; the name may be dotted
+// as in @db.VarChar.
+func (g *grammar) attribute() (*attribute, error) {
+	at := g.next()
+	name, err := g.ident()
+	if err != nil {
+		return nil, err
+	}
+	a := &attribute{name: name.text, line: at.line}
+	for g.isPunct(".") {
+		g.next()
+		part, err := g.ident()
+		if err != nil {
+			return nil, err
+		}
+		a.name += "." + part.text
+	}
+	if g.isPunct("(") {
+		if a.args, err = g.args(); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// args parses a parenthesized, comma-separated argument list.
+func (g *grammar) args() ([]arg, error) {
+	if err := g.expect("("); err != nil {
+		return nil, err
+	}
+	var args []arg
+	for !g.isPunct(")") {
+		var a arg
+		if g.peek().kind == tokIdent && g.toks[g.pos+1].kind == tokPunct && g.toks[g.pos+1].text == ":" {
+			a.name = g.next().text
+			g.next()
+		}
+		v, err := g.value()
+		if err != nil {
+			return nil, err
+		}
+		a.val = v
+		args = append(args, a)
+		if !g.isPunct(",") {
+			break
+		}
+		g.next()
+	}
+	if err := g.expect(")"); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+func (g *grammar) value() (value, error) {
+	t := g.peek()
+	switch {
+	case t.kind == tokString:
+		g.next()
+		return value{kind: valString, text: t.text}, nil
+	case t.kind == tokNumber:
+		g.next()
+		return value{kind: valNumber, text: t.text}, nil
+	case t.kind == tokPunct && t.text == "[":
+		g.next()
+		v := value{kind: valList}
+		for !g.isPunct("]") {
+			item, err := g.value()
+			if err != nil {
+				return value{}, err
+			}
+			v.items = append(v.items, item)
+			if !g.isPunct(",") {
+				break
+			}
+			g.next()
+		}
+		return v, g.expect("]")
+	case t.kind == tokIdent:
+		g.next()
+		v := value{kind: valIdent, text: t.text}
+		if g.isPunct("(") {
+			args, err := g.args()
+			if err != nil {
+				return value{}, err
+			}
+			v.kind, v.args = valCall, args
+		}
+		return v, nil
+	}
+	return value{}, g.errorf(t, "expected a value, found %s", t)
+}
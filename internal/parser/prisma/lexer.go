@@ -0,0 +1,139 @@
+package prisma
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNewline
+	tokIdent
+	tokString
+	tokNumber
+	tokDoc // "///" documentation comment
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of file"
+	case tokNewline:
+		return "end of line"
+	case tokString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// lex splits a Prisma schema into tokens. Line breaks are significant in
+// Prisma (one field per line), so they are kept as tokens; ordinary "//"
+// comments are dropped, "///" documentation comments are kept.
+func lex(src string) ([]token, error) {
+	var toks []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			toks = append(toks, token{kind: tokNewline, text: "\n", line: line})
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			if text, ok := strings.CutPrefix(src[i:i+end], "///"); ok {
+				toks = append(toks, token{kind: tokDoc, text: strings.TrimSpace(text), line: line})
+			}
+			i += end
+		case c == '"':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, &ParseError{Line: line, Err: err}
+			}
+			toks = append(toks, token{kind: tokString, text: s, line: line})
+			i += n
+		case c == '@':
+			n := 1
+			if strings.HasPrefix(src[i:], "@@") {
+				n = 2
+			}
+			toks = append(toks, token{kind: tokPunct, text: src[i : i+n], line: line})
+			i += n
+		case isIdentByte(c) && !isDigit(c):
+			j := i
+			for j < len(src) && isIdentByte(src[j]) {
+				j++
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[i:j], line: line})
+			i = j
+		case isDigit(c) || (c == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			j := i + 1
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[i:j], line: line})
+			i = j
+		case strings.ContainsRune("{}()[],:=?.", rune(c)):
+			toks = append(toks, token{kind: tokPunct, text: string(c), line: line})
+			i++
+		default:
+			return nil, &ParseError{Line: line, Err: fmt.Errorf("unexpected character %q", c)}
+		}
+	}
+	return append(toks, token{kind: tokEOF, line: line}), nil
+}
+
+// lexString reads a double-quoted string with backslash escapes and returns
+// its value and the number of bytes consumed.
+func lexString(s string) (string, int, error) {
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\n':
+			return "", 0, errors.New("unterminated string")
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, errors.New("unterminated string")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(s[i])
+			}
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return "", 0, errors.New("unterminated string")
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || isDigit(c) || unicode.IsLetter(rune(c))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,226 @@
+// Package prisma imports Prisma schema files (schema.prisma) into the
+// canonical core.Database representation, so projects moving to smf can
+// diff and lint their existing schema. Models become tables, scalar fields
+// become columns, @relation fields become foreign keys and enums become enum
+// columns. Constraint and index names follow Prisma's defaults unless a
+// map: argument overrides them, matching what Prisma Migrate creates.
+package prisma
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// Parser reads Prisma schema files.
+type Parser struct {
+	warnings []core.Warning
+}
+
+// NewParser creates a new Prisma schema parser.
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// ParseFile opens the file at the given path and parses it as a Prisma
+// schema. The database is named after the file.
+func (p *Parser) ParseFile(path string) (*core.Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("prisma: open file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	return p.parse(f, path)
+}
+
+// Parse reads Prisma schema content from the reader. The database is named
+// after the datasource block.
+func (p *Parser) Parse(r io.Reader) (*core.Database, error) {
+	return p.parse(r, "")
+}
+
+// Warnings returns the non-fatal findings of the most recent Parse or
+// ParseFile call: attributes and constructs that were not imported, followed
+// by db.Lint() findings.
+func (p *Parser) Warnings() []core.Warning {
+	return p.warnings
+}
+
+func (p *Parser) parse(r io.Reader, file string) (*core.Database, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("prisma: read error: %w", err)
+	}
+	p.warnings = nil
+
+	toks, err := lex(string(data))
+	if err != nil {
+		return nil, withFile(err, file)
+	}
+	blocks, err := parseBlocks(toks)
+	if err != nil {
+		return nil, withFile(err, file)
+	}
+
+	c := newConverter(blocks)
+	db, err := c.database(file)
+	if err != nil {
+		return nil, withFile(err, file)
+	}
+	p.warnings = c.warnings
+
+	if err := db.Validate(); err != nil {
+		if strings.Contains(err.Error(), "snake_case") {
+			return nil, fmt.Errorf("prisma: %w; map model and field names with @@map and @map", err)
+		}
+		return nil, fmt.Errorf("prisma: %w", err)
+	}
+	p.warnings = append(p.warnings, db.Lint()...)
+
+	return db, nil
+}
+
+func withFile(err error, file string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.File = file
+		return pe
+	}
+	return &ParseError{File: file, Err: err}
+}
+
+// providers maps datasource providers to smf dialects.
+var providers = map[string]core.Dialect{
+	"mysql":       core.DialectMySQL,
+	"postgresql":  core.DialectPostgreSQL,
+	"postgres":    core.DialectPostgreSQL,
+	"cockroachdb": core.DialectPostgreSQL,
+	"sqlite":      core.DialectSQLite,
+	"sqlserver":   core.DialectMSSQL,
+}
+
+// converter turns parsed blocks into core types.
+type converter struct {
+	models   map[string]*block
+	enums    map[string]*block
+	order    []*block
+	warnings []core.Warning
+}
+
+func newConverter(blocks []*block) *converter {
+	c := &converter{models: map[string]*block{}, enums: map[string]*block{}, order: blocks}
+	for _, b := range blocks {
+		switch b.keyword {
+		case "model":
+			c.models[b.name] = b
+		case "enum":
+			c.enums[b.name] = b
+		}
+	}
+	return c
+}
+
+func (c *converter) database(file string) (*core.Database, error) {
+	db := &core.Database{}
+	for _, b := range c.order {
+		switch b.keyword {
+		case "datasource":
+			provider, ok := b.props["provider"]
+			if !ok || provider.kind != valString {
+				return nil, &ParseError{Line: b.line, Err: fmt.Errorf("datasource %q has no provider", b.name)}
+			}
+			dialect, ok := providers[provider.text]
+			if !ok {
+				return nil, &ParseError{Line: b.line, Err: fmt.Errorf("unsupported datasource provider %q", provider.text)}
+			}
+			db.Dialect = new(dialect)
+			db.Name = b.name
+		case "model":
+			if b.attr("ignore") != nil {
+				c.warn(b.name, "", "model %q is marked @@ignore and was not imported", b.name)
+				continue
+			}
+			t, err := c.table(b)
+			if err != nil {
+				return nil, err
+			}
+			db.Tables = append(db.Tables, t)
+		case "view", "type":
+			c.warn(b.name, "", "%s %q is not supported and was not imported", b.keyword, b.name)
+		}
+	}
+	if db.Dialect == nil {
+		return nil, errors.New("no datasource block; its provider selects the dialect")
+	}
+	if file != "" {
+		db.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	return db, nil
+}
+
+// tableName returns the database name of a model.
+func tableName(b *block) string {
+	if a := b.attr("map"); a != nil {
+		if v, ok := a.arg("name", 0); ok && v.kind == valString {
+			return v.text
+		}
+	}
+	return b.name
+}
+
+// columnName returns the database name of a model field.
+func (c *converter) columnName(model, fieldName string) string {
+	b, ok := c.models[model]
+	if !ok {
+		return fieldName
+	}
+	for _, f := range b.fields {
+		if f.name != fieldName {
+			continue
+		}
+		if a := f.attr("map"); a != nil {
+			if v, ok := a.arg("name", 0); ok && v.kind == valString {
+				return v.text
+			}
+		}
+	}
+	return fieldName
+}
+
+func (c *converter) table(b *block) (*core.Table, error) {
+	t := &core.Table{Name: tableName(b), Comment: b.doc}
+	for _, f := range b.fields {
+		if err := c.field(b, t, f); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range b.attrs {
+		if err := c.blockAttribute(b, t, a); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// warn records a construct that was not imported. fieldName may be empty
+// for model-level findings.
+func (c *converter) warn(model, fieldName, format string, args ...any) {
+	w := core.Warning{Code: core.WarningUnsupportedFeature, Path: model}
+	where := fmt.Sprintf("model %q", model)
+	if m, ok := c.models[model]; ok {
+		w.Table = tableName(m)
+	}
+	if fieldName != "" {
+		w.Path += "." + fieldName
+		w.Object = fmt.Sprintf("field %q", fieldName)
+		where += fmt.Sprintf(", field %q", fieldName)
+	}
+	w.Message = fmt.Sprintf(format, args...) + " (" + where + ")"
+	c.warnings = append(c.warnings, w)
+}
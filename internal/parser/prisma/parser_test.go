@@ -0,0 +1,261 @@
+package prisma
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+func testdataPath(file string) string {
+	_, filename, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(filename)
+	return filepath.Join(dir, "..", "..", "..", "test", "data", file)
+}
+
+func parseBlog(t *testing.T) (*core.Database, []core.Warning) {
+	t.Helper()
+	p := NewParser()
+	db, err := p.ParseFile(testdataPath("prisma/blog.prisma"))
+	require.NoError(t, err)
+	return db, p.Warnings()
+}
+
+func TestParseFileModels(t *testing.T) {
+	db, _ := parseBlog(t)
+
+	assert.Equal(t, "blog", db.Name)
+	require.NotNil(t, db.Dialect)
+	assert.Equal(t, core.DialectPostgreSQL, *db.Dialect)
+
+	var names []string
+	for _, tbl := range db.Tables {
+		names = append(names, tbl.Name)
+	}
+	assert.Equal(t, []string{"users", "profiles", "posts", "post_tags"}, names)
+
+	users := db.FindTable("users")
+	require.NotNil(t, users)
+	assert.Equal(t, "People who can sign in.", users.Comment)
+
+	id := users.FindColumn("id")
+	require.NotNil(t, id)
+	assert.True(t, id.AutoIncrement)
+	assert.Equal(t, core.DataTypeInt, id.Type)
+
+	email := users.FindColumn("email")
+	require.NotNil(t, email)
+	assert.Equal(t, "VARCHAR(255)", email.RawType)
+	assert.NotNil(t, users.FindConstraint("users_email_key"))
+
+	assert.True(t, users.FindColumn("name").Nullable)
+	assert.Nil(t, users.FindColumn("posts"), "back relations have no column")
+
+	role := users.FindColumn("role")
+	require.NotNil(t, role)
+	assert.Equal(t, core.DataTypeEnum, role.Type)
+	assert.Equal(t, []string{"USER", "admin"}, role.EnumValues)
+	require.NotNil(t, role.DefaultValue)
+	assert.Equal(t, "USER", *role.DefaultValue)
+
+	token := users.FindColumn("token")
+	assert.Equal(t, core.DataTypeUUID, token.Type)
+	assert.Nil(t, token.DefaultValue)
+
+	createdAt := users.FindColumn("created_at")
+	require.NotNil(t, createdAt)
+	assert.Equal(t, "CURRENT_TIMESTAMP", *createdAt.DefaultValue)
+
+	pk := users.PrimaryKey()
+	require.NotNil(t, pk)
+	assert.Equal(t, "users_pkey", pk.Name)
+	assert.Equal(t, []string{"id"}, pk.Columns)
+}
+
+func TestParseFileColumnTypes(t *testing.T) {
+	db, _ := parseBlog(t)
+	posts := db.FindTable("posts")
+	require.NotNil(t, posts)
+
+	tests := []struct {
+		column  string
+		typ     core.DataType
+		raw     string
+		dflt    string
+		hasDflt bool
+	}{
+		{column: "id", typ: core.DataTypeInt, raw: "BIGINT"},
+		{column: "title", typ: core.DataTypeString, raw: "VARCHAR(200)"},
+		{column: "body", typ: core.DataTypeString, raw: "TEXT"},
+		{column: "score", typ: core.DataTypeFloat, raw: "DECIMAL(10,2)", dflt: "0", hasDflt: true},
+		{column: "published", typ: core.DataTypeBoolean, dflt: "FALSE", hasDflt: true},
+		{column: "slug", typ: core.DataTypeString, dflt: "gen_random_uuid()", hasDflt: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			col := posts.FindColumn(tt.column)
+			require.NotNil(t, col)
+			assert.Equal(t, tt.typ, col.Type)
+			assert.Equal(t, tt.raw, col.RawType)
+			if tt.hasDflt {
+				require.NotNil(t, col.DefaultValue)
+				assert.Equal(t, tt.dflt, *col.DefaultValue)
+			} else {
+				assert.Nil(t, col.DefaultValue)
+			}
+		})
+	}
+	assert.Nil(t, posts.FindColumn("tags"), "scalar lists are skipped")
+}
+
+func TestParseFileRelations(t *testing.T) {
+	db, _ := parseBlog(t)
+
+	profileFK := db.FindTable("profiles").FindConstraint("profiles_user_id_fkey")
+	require.NotNil(t, profileFK)
+	assert.Equal(t, core.ConstraintForeignKey, profileFK.Type)
+	assert.Equal(t, []string{"user_id"}, profileFK.Columns)
+	assert.Equal(t, "users", profileFK.ReferencedTable)
+	assert.Equal(t, []string{"id"}, profileFK.ReferencedColumns)
+	assert.Equal(t, core.RefActionCascade, profileFK.OnDelete)
+
+	postFK := db.FindTable("posts").FindConstraint("fk_posts_author")
+	require.NotNil(t, postFK)
+	assert.Equal(t, []string{"author_id"}, postFK.Columns)
+
+	tagFK := db.FindTable("post_tags").FindConstraint("post_tags_post_id_fkey")
+	require.NotNil(t, tagFK)
+	assert.Equal(t, "posts", tagFK.ReferencedTable)
+}
+
+func TestParseFileIndexesAndKeys(t *testing.T) {
+	db, _ := parseBlog(t)
+	posts := db.FindTable("posts")
+
+	uq := posts.FindConstraint("posts_author_id_slug_key")
+	require.NotNil(t, uq)
+	assert.Equal(t, []string{"author_id", "slug"}, uq.Columns)
+
+	idx := posts.FindIndex("idx_posts_author_created")
+	require.NotNil(t, idx)
+	assert.Equal(t, []core.ColumnIndex{{Name: "author_id"}, {Name: "created_at", Order: core.SortDesc}}, idx.Columns)
+
+	hash := posts.FindIndex("posts_title_idx")
+	require.NotNil(t, hash)
+	assert.Equal(t, core.IndexTypeHash, hash.Type)
+
+	pk := db.FindTable("post_tags").PrimaryKey()
+	require.NotNil(t, pk)
+	assert.Equal(t, "post_tags_pkey", pk.Name)
+	assert.Equal(t, []string{"post_id", "tag"}, pk.Columns)
+}
+
+func TestParseFileWarnings(t *testing.T) {
+	_, warnings := parseBlog(t)
+
+	var messages []string
+	for _, w := range warnings {
+		if w.Code == core.WarningUnsupportedFeature {
+			messages = append(messages, w.Message)
+		}
+	}
+	assert.Equal(t, []string{
+		`@default(uuid()) is generated by Prisma Client; no database default was imported (model "User", field "token")`,
+		`@updatedAt is maintained by Prisma Client and has no database equivalent (model "User", field "updatedAt")`,
+		`scalar list type String[] is not supported (model "Post", field "tags")`,
+		`unsupported attribute "@deprecated" (model "Post", field "createdAt")`,
+		`unsupported attribute "@@schema" (model "PostTag")`,
+		`view "PostStats" is not supported and was not imported (model "PostStats")`,
+	}, messages)
+
+	for _, w := range warnings {
+		if strings.HasSuffix(w.Path, ".createdAt") {
+			assert.Equal(t, "posts", w.Table)
+			assert.Equal(t, `field "createdAt"`, w.Object)
+			assert.Equal(t, "Post.createdAt", w.Path)
+		}
+	}
+}
+
+func TestParseNameFromDatasource(t *testing.T) {
+	const schema = `
+datasource main {
+  provider = "sqlserver"
+}
+
+model Item {
+  id Int @id
+
+  @@map("items")
+}
+`
+	db, err := NewParser().Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Equal(t, "main", db.Name)
+	assert.Equal(t, core.DialectMSSQL, *db.Dialect)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "no datasource",
+			schema: "model Item {\n  id Int @id\n}\n",
+			want:   "prisma: no datasource block",
+		},
+		{
+			name:   "unsupported provider",
+			schema: "datasource db {\n  provider = \"mongodb\"\n}\n",
+			want:   `prisma: line 1: unsupported datasource provider "mongodb"`,
+		},
+		{
+			name:   "unknown type",
+			schema: "datasource db {\n  provider = \"mysql\"\n}\n\nmodel Item {\n  id Int @id\n  at Timestamp\n}\n",
+			want:   `prisma: line 7: field "at": unknown type "Timestamp"`,
+		},
+		{
+			name:   "unclosed block",
+			schema: "datasource db {\n  provider = \"mysql\"\n}\n\nmodel Item {\n  id Int @id\n",
+			want:   `prisma: line 7: model "Item" is not closed`,
+		},
+		{
+			name:   "unmapped names",
+			schema: "datasource db {\n  provider = \"mysql\"\n}\n\nmodel Item {\n  id Int @id\n}\n",
+			want:   `"Item" must be in snake_case; map model and field names with @@map and @map`,
+		},
+		{
+			name:   "unterminated string",
+			schema: "datasource db {\n  provider = \"mysql\n}\n",
+			want:   "prisma: line 2: unterminated string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().Parse(strings.NewReader(tt.schema))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseExportedPrismaSchema(t *testing.T) {
+	p := NewParser()
+	db, err := p.ParseFile(testdataPath("gen/shop.prisma.golden"))
+	require.NoError(t, err)
+
+	orders := db.FindTable("orders")
+	require.NotNil(t, orders)
+	fk := orders.FindConstraint("fk_orders_users")
+	require.NotNil(t, fk)
+	assert.Equal(t, "users", fk.ReferencedTable)
+	assert.Equal(t, core.RefActionCascade, fk.OnDelete)
+	assert.Equal(t, []string{"pending", "in-progress", "shipped"}, orders.FindColumn("status").EnumValues)
+}
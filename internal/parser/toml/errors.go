@@ -0,0 +1,179 @@
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"smf/internal/core"
+)
+
+// ErrDecode is wrapped by every ParseError caused by malformed TOML or a value
+// of the wrong type.
+var ErrDecode = errors.New("decode error")
+
+// SchemaFormatError reports a schema_format this parser cannot read.
+type SchemaFormatError struct {
+	// Format is the schema_format declared by the document.
+	Format int
+}
+
+func (e *SchemaFormatError) Error() string {
+	if e.Format < 0 {
+		return fmt.Sprintf("invalid schema_format %d", e.Format)
+	}
+	return fmt.Sprintf("schema_format %d is newer than the supported format %d; upgrade smf to read this file", e.Format, SchemaFormat)
+}
+
+// defaultMaxErrors caps how many independent table failures are reported in
+// a single error when Parser.MaxErrors is not set.
+const defaultMaxErrors = 10
+
+// ParseError is returned when a schema file cannot be parsed. It carries the
+// source location of the failure so users can jump straight to the offending
+// part of a large schema file.
+type ParseError struct {
+	// File is the path of the schema file; empty when parsing from a reader.
+	File string
+	// Line is the 1-based line number of the failure (0 when unknown).
+	Line int
+	// Column is the 1-based column number of the failure (0 when unknown).
+	Column int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	loc := e.location()
+	if loc == "" {
+		return "toml: " + e.Err.Error()
+	}
+	return "toml: " + loc + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// location renders "file:line:col" when the file is known and
+// "line N, column M" otherwise, omitting the parts that are unknown.
+func (e *ParseError) location() string {
+	if e.File == "" {
+		switch {
+		case e.Line > 0 && e.Column > 0:
+			return fmt.Sprintf("line %d, column %d", e.Line, e.Column)
+		case e.Line > 0:
+			return fmt.Sprintf("line %d", e.Line)
+		default:
+			return ""
+		}
+	}
+	parts := []string{e.File}
+	if e.Line > 0 {
+		parts = append(parts, strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			parts = append(parts, strconv.Itoa(e.Column))
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// columnError marks a failure that belongs to a specific column of a table so
+// that the location can be narrowed down to the column's header line.
+type columnError struct {
+	index int
+	name  string
+	err   error
+}
+
+func (e *columnError) Error() string {
+	return fmt.Sprintf("column %d (%q): %v", e.index, e.name, e.err)
+}
+
+func (e *columnError) Unwrap() error {
+	return e.err
+}
+
+// typeErrorRe matches the plain errors the decoder returns for type
+// mismatches, e.g. `toml: line 12 (last key "tables.columns.nullable"): ...`.
+var typeErrorRe = regexp.MustCompile(`^toml: line (\d+) \(last key "([^"]*)"\): (.*)$`)
+
+// decodeError converts an error returned by the TOML decoder into a ParseError,
+// keeping the line and column reported by the decoder when available.
+func decodeError(file string, err error) *ParseError {
+	pe := &ParseError{File: file, Err: fmt.Errorf("%w: %w", ErrDecode, err)}
+	var tomlErr toml.ParseError
+	if errors.As(err, &tomlErr) {
+		pe.Line = tomlErr.Position.Line
+		pe.Column = tomlErr.Position.Col
+		pe.Err = fmt.Errorf("%w: %s", ErrDecode, tomlErr.Message)
+		return pe
+	}
+	if m := typeErrorRe.FindStringSubmatch(err.Error()); m != nil {
+		pe.Line, _ = strconv.Atoi(m[1])
+		pe.Err = fmt.Errorf("%w: key %q: %s", ErrDecode, m[2], m[3])
+	}
+	return pe
+}
+
+// tableError wraps a failure of the table at index idx with its location.
+func tableError(file string, src *sourceMap, idx int, name string, err error) *ParseError {
+	line := src.tableLine(idx)
+	var colErr *columnError
+	if errors.As(err, &colErr) {
+		if l := src.columnLine(idx, colErr.index); l > 0 {
+			line = l
+		}
+	}
+	return &ParseError{
+		File: file,
+		Line: line,
+		Err:  fmt.Errorf("table %d (%q): %w", idx, name, err),
+	}
+}
+
+// validationSubjectRe matches the `table "x"` / `table "x", column "y"`
+// prefix core puts on validation errors.
+var validationSubjectRe = regexp.MustCompile(`^table "([^"]*)"(?:, column "([^"]*)")?`)
+
+// validationError attaches the location of the offending table or column to
+// an error returned by db.Validate. Joined errors are located one by one.
+func validationError(file string, src *sourceMap, sf *schemaFile, err error) error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		located := make([]error, len(errs))
+		for i, e := range errs {
+			located[i] = validationError(file, src, sf, e)
+		}
+		return errors.Join(located...)
+	}
+	pe := &ParseError{File: file, Err: err}
+	var we *core.WarningError
+	if errors.As(err, &we) && we.Warning.Path != "" {
+		pe.Line = src.locate(we.Warning.Path)
+		return pe
+	}
+	m := validationSubjectRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return pe
+	}
+	for i := range sf.Tables {
+		if sf.Tables[i].Name != m[1] {
+			continue
+		}
+		pe.Line = src.tableLine(i)
+		for j := range sf.Tables[i].Columns {
+			if m[2] != "" && sf.Tables[i].Columns[j].Name == m[2] {
+				if l := src.columnLine(i, j); l > 0 {
+					pe.Line = l
+				}
+			}
+		}
+		break
+	}
+	return pe
+}
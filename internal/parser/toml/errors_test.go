@@ -0,0 +1,202 @@
+package toml
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+func TestParseDecodeErrorHasLineAndColumn(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "items"
+comment = "unterminated
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.Error(t, err)
+
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, 8, pe.Line)
+	assert.Positive(t, pe.Column)
+	assert.Empty(t, pe.File)
+	assert.Contains(t, err.Error(), "line 8, column")
+	assert.Contains(t, err.Error(), "decode error")
+}
+
+func TestParseFileDecodeErrorHasFileName(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = = "items"
+`
+	path := filepath.Join(t.TempDir(), "broken.toml")
+	require.NoError(t, os.WriteFile(path, []byte(schema), 0o600))
+
+	p := NewParser()
+	_, err := p.ParseFile(path)
+	require.Error(t, err)
+
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, path, pe.File)
+	assert.Equal(t, 7, pe.Line)
+	assert.Contains(t, err.Error(), path+":7:")
+}
+
+func TestParseTypeMismatchReportsLine(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name     = "id"
+  type     = "int"
+  nullable = "yes"
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "toml: line 12: decode error")
+	assert.Contains(t, err.Error(), "nullable")
+}
+
+func TestParseErrorFormatting(t *testing.T) {
+	base := errors.New("boom")
+	tests := []struct {
+		name string
+		err  *ParseError
+		want string
+	}{
+		{name: "no location", err: &ParseError{Err: base}, want: "toml: boom"},
+		{name: "line only", err: &ParseError{Line: 3, Err: base}, want: "toml: line 3: boom"},
+		{name: "line and column", err: &ParseError{Line: 3, Column: 9, Err: base}, want: "toml: line 3, column 9: boom"},
+		{name: "file only", err: &ParseError{File: "s.toml", Err: base}, want: "toml: s.toml: boom"},
+		{name: "file and line", err: &ParseError{File: "s.toml", Line: 3, Err: base}, want: "toml: s.toml:3: boom"},
+		{name: "full", err: &ParseError{File: "s.toml", Line: 3, Column: 9, Err: base}, want: "toml: s.toml:3:9: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+			assert.ErrorIs(t, tt.err, base)
+		})
+	}
+}
+
+func TestTableErrorUsesColumnLine(t *testing.T) {
+	const schema = `[database]
+name = "testdb"
+
+[[tables]]
+name = "a"
+
+  [[tables.columns]]
+  name = "id"
+
+[[ tables ]]
+name = "b"
+
+  [[tables.columns]]
+  name = "id"
+
+  [[tables.columns]] # second column
+  name = "other"
+`
+	src := newSourceMap([]byte(schema))
+	require.Equal(t, 2, src.arrays["tables"])
+	assert.Equal(t, 4, src.tableLine(0))
+	assert.Equal(t, 10, src.tableLine(1))
+	assert.Equal(t, 7, src.columnLine(0, 0))
+	assert.Equal(t, 16, src.columnLine(1, 1))
+	assert.Zero(t, src.columnLine(1, 2))
+	assert.Zero(t, src.tableLine(5))
+	assert.Equal(t, 17, src.line("tables[1].columns[1].name"))
+
+	err := tableError("s.toml", src, 1, "b", &columnError{index: 1, name: "other", err: errors.New("bad")})
+	assert.Equal(t, 16, err.Line)
+	assert.Equal(t, `toml: s.toml:16: table 1 ("b"): column 1 ("other"): bad`, err.Error())
+
+	err = tableError("s.toml", src, 0, "a", errors.New("bad"))
+	assert.Equal(t, 4, err.Line)
+}
+
+func TestParseValidationErrorHasLine(t *testing.T) {
+	const schema = `[database]
+name    = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "orders"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name       = "user_id"
+  type       = "int"
+  references = "users.id"
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.Error(t, err)
+
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, 5, pe.Line)
+	assert.Equal(t, `toml: line 5: table "orders", constraint "fk_orders_users": references non-existent table "users"`, err.Error())
+
+	var re *core.ReferenceError
+	require.ErrorAs(t, err, &re)
+	assert.Equal(t, "orders", re.Table)
+}
+
+func TestParseValidationErrorUsesColumnLine(t *testing.T) {
+	const schema = `[database]
+name    = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+  nullable    = true
+`
+	_, err := NewParser().Parse(strings.NewReader(schema))
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, 8, pe.Line)
+}
+
+func TestDecodeAndSchemaFormatErrorsAreTyped(t *testing.T) {
+	_, err := NewParser().Parse(strings.NewReader("[database\n"))
+	require.ErrorIs(t, err, ErrDecode)
+
+	_, err = NewParser().Parse(strings.NewReader("schema_format = 99\n"))
+	var sfe *SchemaFormatError
+	require.ErrorAs(t, err, &sfe)
+	assert.Equal(t, 99, sfe.Format)
+}
@@ -5,6 +5,8 @@
 package toml
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -19,11 +21,47 @@ import (
 // In the new schema format, [database], [validation], and [[tables]]
 // are all top-level keys (tables and validation are NOT nested under a database).
 type schemaFile struct {
-	Database   tomlDatabase    `toml:"database"`
-	Validation *tomlValidation `toml:"validation"`
-	Tables     []tomlTable     `toml:"tables"`
+	SchemaFormat int             `toml:"schema_format"`
+	Database     tomlDatabase    `toml:"database"`
+	Validation   *tomlValidation `toml:"validation"`
+	Tables       []tomlTable     `toml:"tables"`
+	Extensions   []tomlExtension `toml:"extensions"`
+	Enums        []tomlEnumType  `toml:"enums"`
+	Domains      []tomlDomain    `toml:"domains"`
+
+	Types map[string]tomlTypeAlias `toml:"types"`
+}
+
+// tomlExtension maps [[extensions]].
+type tomlExtension struct {
+	Name    string `toml:"name"`
+	Schema  string `toml:"schema"`
+	Version string `toml:"version"`
 }
 
+// tomlEnumType maps [[enums]].
+type tomlEnumType struct {
+	Name    string   `toml:"name"`
+	Values  []string `toml:"values"`
+	Comment string   `toml:"comment"`
+}
+
+// tomlDomain maps [[domains]].
+type tomlDomain struct {
+	Name    string `toml:"name"`
+	Type    string `toml:"type"`
+	NotNull bool   `toml:"not_null"`
+	Default any    `toml:"default"`
+	Check   string `toml:"check"`
+	Comment string `toml:"comment"`
+}
+
+// SchemaFormat is the newest version of the TOML layout this parser reads.
+// It is bumped whenever the layout changes in a way older releases cannot
+// read; files without a top-level schema_format key are treated as format 1.
+// It is unrelated to any version of the user's own schema.
+const SchemaFormat = 1
+
 // tomlDatabase maps [database].
 type tomlDatabase struct {
 	Name    string `toml:"name"`
@@ -36,10 +74,40 @@ type tomlValidation struct {
 	MaxColumnNameLength         int    `toml:"max_column_name_length"`
 	AutoGenerateConstraintNames bool   `toml:"auto_generate_constraint_names"`
 	AllowedNamePattern          string `toml:"allowed_name_pattern"`
+	StrictKeys                  bool   `toml:"strict_keys"`
+	StrictDialectOptions        bool   `toml:"strict_dialect_options"`
+	TimestampPolicy             string `toml:"timestamp_policy"`
+	RequirePrimaryKey           bool   `toml:"require_primary_key"`
+
+	Naming *tomlNamingRules `toml:"naming"`
+}
+
+// tomlNamingRules maps [validation.naming].
+type tomlNamingRules struct {
+	PrimaryKey string `toml:"primary_key"`
+	Unique     string `toml:"unique"`
+	ForeignKey string `toml:"foreign_key"`
+	Check      string `toml:"check"`
+	Exclusion  string `toml:"exclusion"`
+	Index      string `toml:"index"`
 }
 
 // Parser reads smf TOML schema files.
-type Parser struct{}
+type Parser struct {
+	// MaxErrors caps how many independent table failures are collected into
+	// a single error. Zero means defaultMaxErrors.
+	MaxErrors int
+
+	warnings []core.Warning
+	// userTypes holds the names of the [[enums]] and [[domains]] of the
+	// document being parsed; column types naming one of them refer to it.
+	userTypes map[string]bool
+	// typeAliases holds the expanded [types] of the document being parsed.
+	typeAliases map[string]typeAlias
+	// dialect is the dialect of the document being parsed, which alias checks
+	// are tokenized for.
+	dialect core.Dialect
+}
 
 // NewParser creates a new TOML schema parser.
 func NewParser() *Parser {
@@ -54,14 +122,35 @@ func (p *Parser) ParseFile(path string) (*core.Database, error) {
 	}
 	defer f.Close()
 
-	return p.Parse(f)
+	return p.parse(f, path)
 }
 
 // Parse reads TOML content from the reader and returns the corresponding core.Database.
 func (p *Parser) Parse(r io.Reader) (*core.Database, error) {
+	return p.parse(r, "")
+}
+
+// parse does the work for Parse and ParseFile. file is only used to annotate
+// errors with their location.
+func (p *Parser) parse(r io.Reader, file string) (*core.Database, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("toml: read error: %w", err)
+	}
+
+	p.warnings = nil
+
 	var sf schemaFile
-	if _, err := toml.NewDecoder(r).Decode(&sf); err != nil {
-		return nil, fmt.Errorf("toml: decode error: %w", err)
+	if _, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&sf); err != nil {
+		return nil, decodeError(file, err)
+	}
+	src := newSourceMap(data)
+
+	if err := checkSchemaFormat(file, src, sf.SchemaFormat); err != nil {
+		return nil, err
+	}
+	if err := p.checkUnknownKeys(data, src, file, sf.Validation); err != nil {
+		return nil, err
 	}
 
 	db := &core.Database{
@@ -70,32 +159,134 @@ func (p *Parser) Parse(r io.Reader) (*core.Database, error) {
 		Tables:  make([]*core.Table, 0, len(sf.Tables)),
 	}
 	db.Validation = parseRules(sf.Validation)
+	for _, e := range sf.Extensions {
+		db.Extensions = append(db.Extensions, &core.Extension{Name: e.Name, Schema: e.Schema, Version: e.Version})
+	}
+	p.dialect = *db.Dialect
+	p.parseUserTypes(db, &sf)
+	if err := p.parseTypeAliases(file, src, &sf); err != nil {
+		return nil, err
+	}
+	p.warnings = append(p.warnings, deprecations(&sf)...)
+	p.warnings = append(p.warnings, p.unknownTypes(&sf, db.Dialect)...)
 
+	var errs []error
 	for i := range sf.Tables {
-		t, err := p.parseTable(&sf.Tables[i], i)
+		t, err := p.parseTable(&sf.Tables[i])
 		if err != nil {
-			return nil, fmt.Errorf("toml: table %d (%q): %w", i, sf.Tables[i].Name, err)
+			errs = append(errs, tableError(file, src, i, sf.Tables[i].Name, err))
+			if len(errs) >= p.maxErrors() {
+				break
+			}
+			continue
 		}
 		db.Tables = append(db.Tables, t)
 	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 
 	if err := db.Validate(); err != nil {
-		return nil, fmt.Errorf("toml: %w", err)
+		return nil, validationError(file, src, &sf, err)
+	}
+	if err := p.checkTimestampTypes(db, &sf); err != nil {
+		return nil, validationError(file, src, &sf, err)
+	}
+	p.warnings = append(p.warnings, db.Lint()...)
+	for i := range p.warnings {
+		if p.warnings[i].Line == 0 {
+			p.warnings[i].Line = src.locate(p.warnings[i].Path)
+		}
 	}
 
 	return db, nil
 }
 
+// Warnings returns the non-fatal findings of the most recent Parse or
+// ParseFile call: unknown keys and deprecated syntax, followed by db.Lint()
+// findings for the declared dialect.
+func (p *Parser) Warnings() []core.Warning {
+	return p.warnings
+}
+
+// checkUnknownKeys reports keys that do not map to any schema field. They are
+// recorded as warnings, or returned as errors when [validation] strict_keys
+// is enabled.
+func (p *Parser) checkUnknownKeys(data []byte, src *sourceMap, file string, v *tomlValidation) error {
+	var raw map[string]any
+	if _, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil {
+		return decodeError(file, err)
+	}
+	unknown := findUnknownKeys(raw, src)
+	if v == nil || !v.StrictKeys {
+		for _, k := range unknown {
+			p.warnings = append(p.warnings, k.warning())
+		}
+		return nil
+	}
+	errs := make([]error, 0, len(unknown))
+	for _, k := range unknown {
+		errs = append(errs, &ParseError{File: file, Line: src.line(k.path), Err: &core.WarningError{Warning: k.warning()}})
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Parser) maxErrors() int {
+	if p.MaxErrors > 0 {
+		return p.MaxErrors
+	}
+	return defaultMaxErrors
+}
+
 // parseRules parses [validation] into core.ValidationRules.
 // No validation is performed here — that happens in db.Validate().
 func parseRules(v *tomlValidation) *core.ValidationRules {
 	if v == nil {
 		return &core.ValidationRules{}
 	}
-	return &core.ValidationRules{
+	rules := &core.ValidationRules{
 		MaxTableNameLength:          v.MaxTableNameLength,
 		MaxColumnNameLength:         v.MaxColumnNameLength,
 		AutoGenerateConstraintNames: v.AutoGenerateConstraintNames,
 		AllowedNamePattern:          v.AllowedNamePattern,
+		StrictKeys:                  v.StrictKeys,
+		StrictDialectOptions:        v.StrictDialectOptions,
+		TimestampPolicy:             core.TimestampPolicy(v.TimestampPolicy),
+		RequirePrimaryKey:           v.RequirePrimaryKey,
+	}
+	if n := v.Naming; n != nil {
+		rules.Naming = &core.NamingRules{
+			PrimaryKey: n.PrimaryKey,
+			Unique:     n.Unique,
+			ForeignKey: n.ForeignKey,
+			Check:      n.Check,
+			Exclusion:  n.Exclusion,
+			Index:      n.Index,
+		}
+	}
+	return rules
+}
+
+// parseUserTypes converts [[enums]] and [[domains]] and records their names
+// so columns can refer to them by type.
+func (p *Parser) parseUserTypes(db *core.Database, sf *schemaFile) {
+	p.userTypes = make(map[string]bool, len(sf.Enums)+len(sf.Domains))
+	for _, e := range sf.Enums {
+		db.EnumTypes = append(db.EnumTypes, &core.EnumType{Name: e.Name, Values: e.Values, Comment: e.Comment})
+		p.userTypes[e.Name] = true
+	}
+	for _, d := range sf.Domains {
+		domain := &core.Domain{
+			Name:     d.Name,
+			BaseType: d.Type,
+			NotNull:  d.NotNull,
+			Check:    d.Check,
+			Comment:  d.Comment,
+		}
+		if d.Default != nil {
+			domain.Default = new(normalizeDefault(d.Default))
+		}
+		db.Domains = append(db.Domains, domain)
+		p.userTypes[d.Name] = true
 	}
 }
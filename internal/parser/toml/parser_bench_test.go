@@ -0,0 +1,72 @@
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"smf/internal/core"
+	"smf/internal/schematest"
+)
+
+var benchSizes = []schematest.Options{
+	{Tables: 10, Columns: 10, Indexes: 2},
+	{Tables: 100, Columns: 10, Indexes: 2},
+	{Tables: 1000, Columns: 10, Indexes: 2},
+}
+
+func benchName(opts schematest.Options) string {
+	return fmt.Sprintf("tables=%d/columns=%d", opts.Tables, opts.Columns)
+}
+
+// BenchmarkParse measures decoding, conversion and validation together, as
+// every command pays for all three.
+func BenchmarkParse(b *testing.B) {
+	for _, opts := range benchSizes {
+		data := schematest.TOML(opts)
+		b.Run(benchName(opts), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := NewParser().Parse(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchDatabase(b *testing.B, opts schematest.Options) *core.Database {
+	b.Helper()
+	db, err := NewParser().Parse(bytes.NewReader(schematest.TOML(opts)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return db
+}
+
+func BenchmarkLint(b *testing.B) {
+	for _, opts := range benchSizes {
+		db := benchDatabase(b, opts)
+		b.Run(benchName(opts), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				db.Lint(core.DialectPostgreSQL)
+			}
+		})
+	}
+}
+
+func BenchmarkFingerprint(b *testing.B) {
+	for _, opts := range benchSizes {
+		db := benchDatabase(b, opts)
+		b.Run(benchName(opts), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := db.Fingerprint(core.FingerprintOptions{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
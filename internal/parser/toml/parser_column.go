@@ -48,6 +48,9 @@ type tomlColumn struct {
 	// Invisible hides the column from SELECT * and some metadata views.
 	Invisible bool `toml:"invisible"`
 
+	// Dialects limits the column to the listed dialects.
+	Dialects []string `toml:"dialects"`
+
 	// Identity / sequence fields for MSSQL, Oracle, DB2, PostgreSQL, Snowflake.
 	IdentitySeed       int64  `toml:"identity_seed"`
 	IdentityIncrement  int64  `toml:"identity_increment"`
@@ -119,6 +122,7 @@ type tomlSQLiteColumnOptions struct {
 }
 
 func (p *Parser) parseColumn(tc *tomlColumn) (*core.Column, error) {
+	tc = p.expandTypeAlias(tc)
 	col := &core.Column{
 		Name:               tc.Name,
 		Nullable:           tc.Nullable,
@@ -136,9 +140,13 @@ func (p *Parser) parseColumn(tc *tomlColumn) (*core.Column, error) {
 		IdentityGeneration: core.IdentityGeneration(tc.IdentityGeneration),
 		SequenceName:       tc.SequenceName,
 		Invisible:          tc.Invisible,
+		Dialects:           parseDialects(tc.Dialects),
 	}
 
-	if err := resolveColumnType(col, tc); err != nil {
+	if p.userTypes[strings.TrimSpace(tc.Type)] {
+		col.UserType = strings.TrimSpace(tc.Type)
+		col.RawType = tc.RawType
+	} else if err := resolveColumnType(col, tc); err != nil {
 		return nil, err
 	}
 
@@ -157,6 +165,21 @@ func resolveColumnType(col *core.Column, tc *tomlColumn) error {
 	}
 
 	col.Type = core.NormalizeDataType(portableType)
+	// Types smf has no portable name for, such as PostgreSQL inet, are
+	// passed to the declared dialect as written; unknownTypes warns about
+	// them.
+	if col.Type == core.DataTypeUnknown && tc.RawType == "" {
+		col.RawType = portableType
+	}
+
+	// Legacy format: values embedded in the type, e.g. type = "enum('a','b')".
+	if len(col.EnumValues) == 0 {
+		if values, ok := core.ParseEnumTypeRaw(portableType); ok {
+			col.EnumValues = values
+		} else if values, ok := core.ParseSetTypeRaw(portableType); ok {
+			col.EnumValues = values
+		}
+	}
 
 	if tc.RawType != "" {
 		col.RawType = tc.RawType
@@ -165,6 +188,24 @@ func resolveColumnType(col *core.Column, tc *tomlColumn) error {
 	return nil
 }
 
+// checkTimestampTypes checks the portable types of date-time columns without
+// a raw type against [validation] timestamp_policy. They are read as written
+// in the declared dialect, so type = "datetime" is naive in MySQL.
+func (p *Parser) checkTimestampTypes(db *core.Database, sf *schemaFile) error {
+	for _, tt := range sf.Tables {
+		for j := range tt.Columns {
+			tc := p.expandTypeAlias(&tt.Columns[j])
+			if tc.RawType != "" || core.NormalizeDataType(tc.Type) != core.DataTypeDatetime {
+				continue
+			}
+			if err := db.CheckTimestampType(strings.TrimSpace(tc.Type)); err != nil {
+				return fmt.Errorf("table %q, column %q: %w", tt.Name, tc.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // applyColumnActions sets default values, referential actions, on-update
 // behavior, and generated-column properties on an already-initialized column.
 func applyColumnActions(col *core.Column, tc *tomlColumn) {
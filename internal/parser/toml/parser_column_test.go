@@ -311,6 +311,14 @@ name = "items"
   type                  = "int"
   primary_key           = true
 
+  [[tables.columns]]
+  name                  = "first_name"
+  type                  = "varchar(100)"
+
+  [[tables.columns]]
+  name                  = "last_name"
+  type                  = "varchar(100)"
+
   [[tables.columns]]
   name                  = "full_name"
   type                  = "varchar(255)"
@@ -635,3 +643,99 @@ name = "items"
 	assert.Contains(t, err.Error(), "duplicate column name")
 	assert.Contains(t, err.Error(), "id")
 }
+
+func TestParseSetColumn(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name = "id"
+  type = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name    = "perms"
+  type    = "set"
+  values  = ["read", "write", "admin"]
+  default = "read,write"
+
+  [[tables.columns]]
+  name = "flags"
+  type = "set('a','b')"
+`
+	db, err := NewParser().Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+
+	users := db.FindTable("users")
+	perms := users.FindColumn("perms")
+	assert.Equal(t, core.DataTypeSet, perms.Type)
+	assert.Equal(t, []string{"read", "write", "admin"}, perms.EnumValues)
+
+	flags := users.FindColumn("flags")
+	assert.Equal(t, core.DataTypeSet, flags.Type)
+	assert.Equal(t, []string{"a", "b"}, flags.EnumValues)
+}
+
+func TestParseSetColumnDefaultNotSubset(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name    = "perms"
+  type    = "set"
+  values  = ["read", "write"]
+  default = "read,delete"
+`
+	_, err := NewParser().Parse(strings.NewReader(schema))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"delete" is not a member`)
+}
+
+func TestParseMSSQLDataMasking(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mssql"
+
+[[tables]]
+name = "customers"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "phone"
+  type = "varchar(20)"
+
+    [tables.columns.mssql.data_masking]
+    function = 'partial(1,"XXXX",2)'
+
+  [[tables.columns]]
+  name = "credit_limit"
+  type = "int"
+
+    [tables.columns.mssql.data_masking]
+    function = "email()"
+`
+	_, err := NewParser().Parse(strings.NewReader(schema))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `column "credit_limit": mssql: data_masking: email() masks strings, not int columns`)
+
+	db, err := NewParser().Parse(strings.NewReader(strings.Replace(schema, `"email()"`, `"random(1, 1000)"`, 1)))
+	require.NoError(t, err)
+	phone := db.FindTable("customers").FindColumn("phone")
+	require.NotNil(t, phone.MSSQL)
+	assert.Equal(t, &core.MSSQLDataMaskingOptions{Function: `partial(1,"XXXX",2)`}, phone.MSSQL.DataMasking)
+}
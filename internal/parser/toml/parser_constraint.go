@@ -6,15 +6,25 @@ import (
 
 // tomlConstraint maps [[tables.constraints]].
 type tomlConstraint struct {
-	Name              string   `toml:"name"`
-	Type              string   `toml:"type"`
-	Columns           []string `toml:"columns"`
-	ReferencedTable   string   `toml:"referenced_table"`
-	ReferencedColumns []string `toml:"referenced_columns"`
-	OnDelete          string   `toml:"on_delete"`
-	OnUpdate          string   `toml:"on_update"`
-	CheckExpression   string   `toml:"check_expression"`
-	Enforced          *bool    `toml:"enforced"` // pointer: absent -> true
+	Name              string                 `toml:"name"`
+	Type              string                 `toml:"type"`
+	Columns           []string               `toml:"columns"`
+	ReferencedTable   string                 `toml:"referenced_table"`
+	ReferencedColumns []string               `toml:"referenced_columns"`
+	OnDelete          string                 `toml:"on_delete"`
+	OnUpdate          string                 `toml:"on_update"`
+	CheckExpression   string                 `toml:"check_expression"`
+	Enforced          *bool                  `toml:"enforced"` // pointer: absent -> true
+	Expressions       []string               `toml:"expressions"`
+	NullsNotDistinct  bool                   `toml:"nulls_not_distinct"`
+	Using             string                 `toml:"using"`
+	Elements          []tomlExclusionElement `toml:"elements"`
+}
+
+// tomlExclusionElement maps an entry of an EXCLUDE constraint's elements.
+type tomlExclusionElement struct {
+	Expression string `toml:"expression"`
+	Operator   string `toml:"operator"`
 }
 
 func parseTableConstraint(tc *tomlConstraint) *core.Constraint {
@@ -27,6 +37,12 @@ func parseTableConstraint(tc *tomlConstraint) *core.Constraint {
 		OnDelete:          core.ReferentialAction(tc.OnDelete),
 		OnUpdate:          core.ReferentialAction(tc.OnUpdate),
 		CheckExpression:   tc.CheckExpression,
+		Expressions:       tc.Expressions,
+		NullsNotDistinct:  tc.NullsNotDistinct,
+		UsingMethod:       tc.Using,
+	}
+	for _, e := range tc.Elements {
+		c.Elements = append(c.Elements, core.ExclusionElement{Expression: e.Expression, Operator: e.Operator})
 	}
 
 	if tc.Enforced != nil {
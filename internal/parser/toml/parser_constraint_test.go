@@ -662,3 +662,95 @@ name = "items"
 	assert.Contains(t, err.Error(), "nonexistent column")
 	assert.Contains(t, err.Error(), "ghost")
 }
+
+func TestParseUniqueExpressions(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "postgresql"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name = "id"
+  type = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name     = "tenant_id"
+  type     = "int"
+  nullable = true
+
+  [[tables.columns]]
+  name = "email"
+  type = "varchar(255)"
+
+  [[tables.constraints]]
+  name               = "uq_users_tenant_email"
+  type               = "UNIQUE"
+  columns            = ["tenant_id"]
+  expressions        = ["lower(email)"]
+  nulls_not_distinct = true
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+
+	con := db.FindTable("users").FindConstraint("uq_users_tenant_email")
+	require.NotNil(t, con)
+	assert.Equal(t, []string{"tenant_id"}, con.Columns)
+	assert.Equal(t, []string{"lower(email)"}, con.Expressions)
+	assert.True(t, con.NullsNotDistinct)
+}
+
+func TestParseExclusionConstraint(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "postgresql"
+
+[[tables]]
+name = "bookings"
+
+  [[tables.columns]]
+  name = "id"
+  type = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "room_id"
+  type = "int"
+
+  [[tables.columns]]
+  name = "during"
+  type = "text"
+
+  [[tables.constraints]]
+  name  = "ex_bookings_room_during"
+  type  = "EXCLUDE"
+  using = "gist"
+
+    [[tables.constraints.elements]]
+    expression = "room_id"
+    operator   = "="
+
+    [[tables.constraints.elements]]
+    expression = "during"
+    operator   = "&&"
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+
+	con := db.FindTable("bookings").FindConstraint("ex_bookings_room_during")
+	require.NotNil(t, con)
+	assert.Equal(t, core.ConstraintExclusion, con.Type)
+	assert.Equal(t, "gist", con.UsingMethod)
+	assert.Equal(t, []core.ExclusionElement{
+		{Expression: "room_id", Operator: "="},
+		{Expression: "during", Operator: "&&"},
+	}, con.Elements)
+}
@@ -12,6 +12,9 @@ type tomlIndex struct {
 	Comment    string `toml:"comment"`
 	Visibility string `toml:"visibility"`
 
+	// Dialects limits the index to the listed dialects.
+	Dialects []string `toml:"dialects"`
+
 	// Simple form: columns = ["tenant_id", "created_at"]
 	Columns []string `toml:"columns"`
 
@@ -28,9 +31,10 @@ type tomlColumnIndex struct {
 
 func parseTableIndex(ti *tomlIndex) *core.Index {
 	idx := &core.Index{
-		Name:    ti.Name,
-		Unique:  ti.Unique,
-		Comment: ti.Comment,
+		Name:     ti.Name,
+		Unique:   ti.Unique,
+		Comment:  ti.Comment,
+		Dialects: parseDialects(ti.Dialects),
 	}
 
 	if ti.Type != "" {
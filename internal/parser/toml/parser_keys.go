@@ -0,0 +1,195 @@
+package toml
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// unknownKey describes a key present in the document that has no matching
+// field in the schema structs.
+type unknownKey struct {
+	path   string // indexed key path, e.g. "tables[1].columns[0].nullabe"
+	key    string // the unrecognized key itself
+	table  string // name of the enclosing table, if any
+	object string // human description of the enclosing object, if any
+}
+
+// arrayNouns names the objects stored in each array of tables (or table keyed
+// by name, such as [types]) so warnings can say `column "email"` instead of
+// `columns[3]`.
+var arrayNouns = map[string]string{
+	"tables":      "table",
+	"columns":     "column",
+	"constraints": "constraint",
+	"indexes":     "index",
+	"column_defs": "index column",
+	"elements":    "element",
+	"policies":    "policy",
+	"extensions":  "extension",
+	"enums":       "enum type",
+	"domains":     "domain",
+	"types":       "type alias",
+}
+
+// findUnknownKeys walks the generically decoded document alongside the schema
+// structs and reports every key without a matching `toml` tag, ordered by
+// their position in the source.
+func findUnknownKeys(raw map[string]any, src *sourceMap) []unknownKey {
+	w := &keyWalker{}
+	w.walkTable(raw, reflect.TypeFor[schemaFile](), keyContext{})
+	sort.SliceStable(w.found, func(i, j int) bool {
+		li, lj := src.line(w.found[i].path), src.line(w.found[j].path)
+		if li != lj {
+			return li < lj
+		}
+		return w.found[i].path < w.found[j].path
+	})
+	return w.found
+}
+
+type keyContext struct {
+	path   string
+	table  string
+	object string
+}
+
+type keyWalker struct {
+	found []unknownKey
+}
+
+func (w *keyWalker) walkTable(m map[string]any, t reflect.Type, ctx keyContext) {
+	fields := tomlFields(t)
+	for key, value := range m {
+		child := ctx
+		child.path = joinPath(ctx.path, key)
+		ft, ok := fields[key]
+		if !ok {
+			w.found = append(w.found, unknownKey{path: child.path, key: key, table: ctx.table, object: ctx.object})
+			continue
+		}
+		w.walkValue(value, ft, key, child)
+	}
+}
+
+func (w *keyWalker) walkValue(v any, t reflect.Type, key string, ctx keyContext) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if m, ok := v.(map[string]any); ok {
+			w.walkTable(m, t, ctx)
+		}
+	case reflect.Slice:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return
+		}
+		for i, item := range tableItems(v) {
+			child := ctx
+			child.path = indexPath(ctx.path, i)
+			describeItem(&child, key, i, item)
+			w.walkTable(item, elem, child)
+		}
+	case reflect.Map:
+		elem := t.Elem()
+		m, ok := v.(map[string]any)
+		if !ok || elem.Kind() != reflect.Struct {
+			return
+		}
+		for name, item := range m {
+			table, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			child := ctx
+			child.path = joinPath(ctx.path, name)
+			child.object = fmt.Sprintf("%s %q", arrayNouns[key], name)
+			w.walkTable(table, elem, child)
+		}
+	}
+}
+
+// describeItem records the human-readable name of an array element.
+func describeItem(ctx *keyContext, key string, i int, item map[string]any) {
+	name, _ := item["name"].(string)
+	if key == "tables" {
+		ctx.table, ctx.object = name, ""
+		return
+	}
+	noun, ok := arrayNouns[key]
+	if !ok {
+		noun = key
+	}
+	label := fmt.Sprintf("%s %d", noun, i)
+	if name != "" {
+		label = fmt.Sprintf("%s %q", noun, name)
+	}
+	if ctx.object != "" {
+		label = ctx.object + ", " + label
+	}
+	ctx.object = label
+}
+
+// tableItems normalizes arrays of tables and arrays of inline tables.
+func tableItems(v any) []map[string]any {
+	switch items := v.(type) {
+	case []map[string]any:
+		return items
+	case []any:
+		out := make([]map[string]any, 0, len(items))
+		for _, item := range items {
+			if m, ok := item.(map[string]any); ok {
+				out = append(out, m)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// tomlFields maps the `toml` tag of every field of t to the field's type.
+func tomlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for f := range t.Fields() {
+		tag, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields[tag] = f.Type
+	}
+	return fields
+}
+
+// warning converts the unknown key into a core.Warning.
+func (k unknownKey) warning() core.Warning {
+	return core.Warning{
+		Code:    core.WarningUnknownKey,
+		Table:   k.table,
+		Object:  k.object,
+		Path:    k.path,
+		Message: k.message(),
+	}
+}
+
+func (k unknownKey) message() string {
+	var where []string
+	if k.table != "" {
+		where = append(where, fmt.Sprintf("table %q", k.table))
+	}
+	if k.object != "" {
+		where = append(where, k.object)
+	}
+	if len(where) == 0 {
+		return fmt.Sprintf("unknown key %q at %s", k.key, k.path)
+	}
+	return fmt.Sprintf("unknown key %q at %s (%s)", k.key, k.path, strings.Join(where, ", "))
+}
@@ -0,0 +1,210 @@
+package toml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+const unknownKeysSchema = `
+schema_fromat = 1
+
+[database]
+name    = "testdb"
+dialect = "mysql"
+dialcet = "mysql"
+
+[validation]
+max_table_name_lenght = 64
+
+[[tables]]
+name  = "users"
+owner = "team-a"
+
+  [tables.options]
+  tablespace = "ts1"
+  engine     = "InnoDB"
+
+  [tables.options.mysql]
+  engine  = "InnoDB"
+  charzet = "utf8mb4"
+
+  [tables.timestamps]
+  enabled = true
+  create_column = "created"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+
+  [[tables.columns]]
+  name     = "email"
+  type     = "varchar(255)"
+  nullabe  = true
+
+    [tables.columns.mssql.always_encrypted]
+    column_encryption_key = "cek"
+    encryption_typ        = "DETERMINISTIC"
+    encryption_type       = "RANDOMIZED"
+
+  [[tables.columns]]
+  name      = "org_id"
+  type      = "bigint"
+  references = "orgs.id"
+  on_detele  = "CASCADE"
+
+  [[tables.constraints]]
+  name    = "uq_users_email"
+  type    = "UNIQUE"
+  columns = ["email"]
+  colums  = ["email"]
+
+  [[tables.indexes]]
+  name    = "idx_users_email"
+  columns = ["email"]
+  uniq    = true
+
+  [[tables.indexes]]
+  name = "idx_users_email_prefix"
+
+    [[tables.indexes.column_defs]]
+    name   = "email"
+    lenght = 10
+
+[[tables]]
+name = "orgs"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+`
+
+func TestParseUnknownKeysReportedAsWarnings(t *testing.T) {
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(unknownKeysSchema))
+	require.NoError(t, err)
+
+	var paths []string
+	for _, w := range p.Warnings() {
+		if w.Code == core.WarningUnknownKey {
+			paths = append(paths, w.Path)
+		}
+	}
+	assert.Equal(t, []string{
+		"schema_fromat",
+		"database.dialcet",
+		"validation.max_table_name_lenght",
+		"tables[0].owner",
+		"tables[0].options.engine",
+		"tables[0].options.mysql.charzet",
+		"tables[0].timestamps.create_column",
+		"tables[0].columns[1].nullabe",
+		"tables[0].columns[1].mssql.always_encrypted.encryption_typ",
+		"tables[0].columns[2].on_detele",
+		"tables[0].constraints[0].colums",
+		"tables[0].indexes[0].uniq",
+		"tables[0].indexes[1].column_defs[0].lenght",
+	}, paths)
+}
+
+func TestParseUnknownKeyWarningContext(t *testing.T) {
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(unknownKeysSchema))
+	require.NoError(t, err)
+
+	byPath := make(map[string]core.Warning)
+	for _, w := range p.Warnings() {
+		byPath[w.Path] = w
+	}
+
+	nullabe := byPath["tables[0].columns[1].nullabe"]
+	assert.Equal(t, "users", nullabe.Table)
+	assert.Equal(t, `column "email"`, nullabe.Object)
+	assert.Equal(t, `unknown key "nullabe" at tables[0].columns[1].nullabe (table "users", column "email")`, nullabe.Message)
+
+	assert.Equal(t, 36, nullabe.Line)
+
+	lenght := byPath["tables[0].indexes[1].column_defs[0].lenght"]
+	assert.Equal(t, `index "idx_users_email_prefix", index column "email"`, lenght.Object)
+
+	dialcet := byPath["database.dialcet"]
+	assert.Empty(t, dialcet.Table)
+	assert.Equal(t, `unknown key "dialcet" at database.dialcet`, dialcet.Message)
+	assert.Equal(t, `unknown-key: unknown key "dialcet" at database.dialcet`, dialcet.String())
+}
+
+func TestParseUnknownKeysStrict(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[validation]
+strict_keys = true
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+  nullabe     = true
+
+  [[tables.columns]]
+  name      = "parent_id"
+  type      = "bigint"
+  on_detele = "CASCADE"
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `toml: line 16: unknown key "nullabe"`)
+	assert.Contains(t, err.Error(), `toml: line 21: unknown key "on_detele"`)
+
+	var we *core.WarningError
+	require.ErrorAs(t, err, &we)
+	assert.Equal(t, core.WarningUnknownKey, we.Warning.Code)
+	assert.Empty(t, p.Warnings())
+}
+
+func TestParseKnownKeysProduceNoWarnings(t *testing.T) {
+	p := NewParser()
+	_, err := p.ParseFile(testdataPath("schema.toml"))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+}
+
+func TestParseWarningsResetBetweenCalls(t *testing.T) {
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(unknownKeysSchema))
+	require.NoError(t, err)
+	require.NotEmpty(t, p.Warnings())
+
+	_, err = p.ParseFile(testdataPath("schema.toml"))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+}
+
+func TestParseStrayDialectOptionsReportedAsWarnings(t *testing.T) {
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(unknownKeysSchema))
+	require.NoError(t, err)
+
+	var stray []core.Warning
+	for _, w := range p.Warnings() {
+		if w.Code == core.WarningStrayDialectOptions {
+			stray = append(stray, w)
+		}
+	}
+	require.Len(t, stray, 1)
+	assert.Equal(t, "tables[0].columns[1].mssql", stray[0].Path)
+	assert.Equal(t, "email", stray[0].Object)
+	assert.Equal(t, 38, stray[0].Line, "located at the first nested header of the group")
+}
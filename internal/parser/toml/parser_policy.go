@@ -0,0 +1,36 @@
+package toml
+
+import (
+	"strings"
+
+	"smf/internal/core"
+)
+
+// tomlPolicy maps [[tables.policies]].
+type tomlPolicy struct {
+	Name       string   `toml:"name"`
+	Command    string   `toml:"command"`
+	Roles      []string `toml:"roles"`
+	Using      string   `toml:"using"`
+	WithCheck  string   `toml:"with_check"`
+	Permissive *bool    `toml:"permissive"`
+}
+
+func parseTablePolicy(table string, tp *tomlPolicy) *core.Policy {
+	p := &core.Policy{
+		Name:       tp.Name,
+		Table:      table,
+		Command:    core.PolicyCommand(strings.ToUpper(tp.Command)),
+		Roles:      tp.Roles,
+		Using:      tp.Using,
+		WithCheck:  tp.WithCheck,
+		Permissive: true,
+	}
+	if p.Command == "" {
+		p.Command = core.PolicyAll
+	}
+	if tp.Permissive != nil {
+		p.Permissive = *tp.Permissive
+	}
+	return p
+}
@@ -1,7 +1,8 @@
 package toml
 
 import (
-	"fmt"
+	"errors"
+	"strings"
 
 	"smf/internal/core"
 )
@@ -22,6 +23,14 @@ type tomlTable struct {
 	Constraints []tomlConstraint `toml:"constraints"`
 	Indexes     []tomlIndex      `toml:"indexes"`
 	Timestamps  *tomlTimestamps  `toml:"timestamps"`
+	Policies    []tomlPolicy     `toml:"policies"`
+
+	RowLevelSecurity bool `toml:"row_level_security"`
+
+	// Dialects limits the table to the listed dialects.
+	Dialects []string `toml:"dialects"`
+
+	AllowNoPrimaryKey bool `toml:"allow_no_primary_key"`
 }
 
 // tomlTimestamps maps [tables.timestamps].
@@ -123,12 +132,15 @@ type tomlOracleTableOptions struct {
 
 // tomlSQLServerTableOptions maps [tables.options.sqlserver].
 type tomlSQLServerTableOptions struct {
-	FileGroup        string `toml:"file_group"`
-	DataCompression  string `toml:"data_compression"`
-	MemoryOptimized  bool   `toml:"memory_optimized"`
-	SystemVersioning bool   `toml:"system_versioning"`
-	TextImageOn      string `toml:"textimage_on"`
-	LedgerTable      bool   `toml:"ledger_table"`
+	FileGroup         string `toml:"file_group"`
+	DataCompression   string `toml:"data_compression"`
+	MemoryOptimized   bool   `toml:"memory_optimized"`
+	SystemVersioning  bool   `toml:"system_versioning"`
+	PeriodStartColumn string `toml:"period_start_column"`
+	PeriodEndColumn   string `toml:"period_end_column"`
+	HistoryTable      string `toml:"history_table"`
+	TextImageOn       string `toml:"textimage_on"`
+	LedgerTable       bool   `toml:"ledger_table"`
 }
 
 // tomlDB2TableOptions maps [tables.options.db2].
@@ -164,11 +176,15 @@ type tomlMariaDBTableOptions struct {
 	WithSystemVersioning bool   `toml:"with_system_versioning"`
 }
 
-func (p *Parser) parseTable(tt *tomlTable, idx int) (*core.Table, error) {
+func (p *Parser) parseTable(tt *tomlTable) (*core.Table, error) {
 	table := &core.Table{
 		Name:    tt.Name,
 		Comment: tt.Comment,
 		Options: parseTableOptions(&tt.Options),
+
+		RowLevelSecurity:  tt.RowLevelSecurity,
+		Dialects:          parseDialects(tt.Dialects),
+		AllowNoPrimaryKey: tt.AllowNoPrimaryKey,
 	}
 
 	if ts := tt.Timestamps; ts != nil {
@@ -179,7 +195,7 @@ func (p *Parser) parseTable(tt *tomlTable, idx int) (*core.Table, error) {
 		}
 	}
 
-	if err := p.parseTableColumns(table, tt, idx); err != nil {
+	if err := p.parseTableColumns(table, tt); err != nil {
 		return nil, err
 	}
 
@@ -195,6 +211,10 @@ func (p *Parser) parseTable(tt *tomlTable, idx int) (*core.Table, error) {
 		table.Indexes = append(table.Indexes, idx)
 	}
 
+	for i := range tt.Policies {
+		table.Policies = append(table.Policies, parseTablePolicy(tt.Name, &tt.Policies[i]))
+	}
+
 	return table, nil
 }
 
@@ -315,12 +335,15 @@ func parseOracleTableOptions(o *tomlOracleTableOptions) *core.OracleTableOptions
 
 func parseSQLServerTableOptions(ss *tomlSQLServerTableOptions) *core.SQLServerTableOptions {
 	return &core.SQLServerTableOptions{
-		FileGroup:        ss.FileGroup,
-		DataCompression:  ss.DataCompression,
-		MemoryOptimized:  ss.MemoryOptimized,
-		SystemVersioning: ss.SystemVersioning,
-		TextImageOn:      ss.TextImageOn,
-		LedgerTable:      ss.LedgerTable,
+		FileGroup:         ss.FileGroup,
+		DataCompression:   ss.DataCompression,
+		MemoryOptimized:   ss.MemoryOptimized,
+		SystemVersioning:  ss.SystemVersioning,
+		PeriodStartColumn: ss.PeriodStartColumn,
+		PeriodEndColumn:   ss.PeriodEndColumn,
+		HistoryTable:      ss.HistoryTable,
+		TextImageOn:       ss.TextImageOn,
+		LedgerTable:       ss.LedgerTable,
 	}
 }
 
@@ -363,15 +386,20 @@ func parseMariaDBTableOptions(mdb *tomlMariaDBTableOptions) *core.MariaDBTableOp
 
 // parseTableColumns populates table.Columns from the TOML column definitions
 // and injects timestamp columns when enabled.
-func (p *Parser) parseTableColumns(table *core.Table, tt *tomlTable, tableIdx int) error {
+func (p *Parser) parseTableColumns(table *core.Table, tt *tomlTable) error {
 	table.Columns = make([]*core.Column, 0, len(tt.Columns))
+	var errs []error
 	for i := range tt.Columns {
 		col, err := p.parseColumn(&tt.Columns[i])
 		if err != nil {
-			return fmt.Errorf("toml: table %d column %d (%q): %w", tableIdx, i, tt.Columns[i].Name, err)
+			errs = append(errs, &columnError{index: i, name: tt.Columns[i].Name, err: err})
+			continue
 		}
 		table.Columns = append(table.Columns, col)
 	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
 
 	if table.Timestamps != nil && table.Timestamps.Enabled {
 		injectTimestampColumns(table)
@@ -417,3 +445,16 @@ func injectTimestampColumns(table *core.Table) {
 		})
 	}
 }
+
+// parseDialects converts a dialects list, lower-casing the names like
+// [database] dialect. Unknown names are rejected by validation.
+func parseDialects(names []string) []core.Dialect {
+	if len(names) == 0 {
+		return nil
+	}
+	dialects := make([]core.Dialect, len(names))
+	for i, n := range names {
+		dialects[i] = core.Dialect(strings.ToLower(strings.TrimSpace(n)))
+	}
+	return dialects
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
 )
 
 func TestParseEmptyTable(t *testing.T) {
@@ -183,14 +185,25 @@ name = "items"
   file_group        = "PRIMARY"
   data_compression  = "PAGE"
   memory_optimized  = true
-  system_versioning = true
-  textimage_on      = "LOB_FG"
-  ledger_table      = true
+  system_versioning   = true
+  period_start_column = "valid_from"
+  period_end_column   = "valid_to"
+  history_table       = "dbo.items_history"
+  textimage_on        = "LOB_FG"
+  ledger_table        = true
 
   [[tables.columns]]
   name = "id"
   type = "int"
   primary_key = true
+
+  [[tables.columns]]
+  name = "valid_from"
+  type = "datetime"
+
+  [[tables.columns]]
+  name = "valid_to"
+  type = "datetime"
 `
 	p := NewParser()
 	db, err := p.Parse(strings.NewReader(schema))
@@ -202,6 +215,9 @@ name = "items"
 	assert.Equal(t, "PAGE", opts.SQLServer.DataCompression)
 	assert.True(t, opts.SQLServer.MemoryOptimized)
 	assert.True(t, opts.SQLServer.SystemVersioning)
+	assert.Equal(t, "valid_from", opts.SQLServer.PeriodStartColumn)
+	assert.Equal(t, "valid_to", opts.SQLServer.PeriodEndColumn)
+	assert.Equal(t, "dbo.items_history", opts.SQLServer.HistoryTable)
 	assert.Equal(t, "LOB_FG", opts.SQLServer.TextImageOn)
 	assert.True(t, opts.SQLServer.LedgerTable)
 }
@@ -360,6 +376,14 @@ name = "items"
   name = "id"
   type = "int"
   primary_key = true
+
+  [[tables.columns]]
+  name = "name"
+  type = "varchar(100)"
+
+  [[tables.columns]]
+  name = "status"
+  type = "varchar(20)"
 `
 	p := NewParser()
 	db, err := p.Parse(strings.NewReader(schema))
@@ -828,3 +852,93 @@ name = "items"
 	require.NoError(t, err)
 	assert.Len(t, db.Tables[0].Columns, 3)
 }
+
+func TestParseRowLevelSecurityPolicies(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "postgresql"
+
+[[tables]]
+name = "documents"
+row_level_security = true
+
+  [[tables.columns]]
+  name = "id"
+  type = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "owner_id"
+  type = "int"
+
+  [[tables.policies]]
+  name       = "documents_owner"
+  roles      = ["app_user"]
+  using      = "owner_id = current_setting('app.user_id')::int"
+  with_check = "owner_id = current_setting('app.user_id')::int"
+
+  [[tables.policies]]
+  name       = "documents_no_archived"
+  command    = "select"
+  using      = "NOT archived"
+  permissive = false
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+
+	table := db.FindTable("documents")
+	assert.True(t, table.RowLevelSecurity)
+	require.Len(t, table.Policies, 2)
+	assert.Equal(t, &core.Policy{
+		Name:       "documents_owner",
+		Table:      "documents",
+		Command:    core.PolicyAll,
+		Roles:      []string{"app_user"},
+		Using:      "owner_id = current_setting('app.user_id')::int",
+		WithCheck:  "owner_id = current_setting('app.user_id')::int",
+		Permissive: true,
+	}, table.Policies[0])
+	assert.Equal(t, core.PolicySelect, table.Policies[1].Command)
+	assert.False(t, table.Policies[1].Permissive)
+}
+
+func TestParseDialectLimits(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name     = "search_cache"
+dialects = ["MySQL", "mariadb"]
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name     = "terms"
+  type     = "text"
+  dialects = ["mysql"]
+
+  [[tables.indexes]]
+  name     = "idx_search_cache_terms"
+  columns  = ["terms"]
+  type     = "FULLTEXT"
+  dialects = ["mysql"]
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+
+	table := db.FindTable("search_cache")
+	assert.Equal(t, []core.Dialect{core.DialectMySQL, core.DialectMariaDB}, table.Dialects)
+	assert.Equal(t, []core.Dialect{core.DialectMySQL}, table.FindColumn("terms").Dialects)
+	assert.Equal(t, []core.Dialect{core.DialectMySQL}, table.FindIndex("idx_search_cache_terms").Dialects)
+	assert.Nil(t, table.FindColumn("id").Dialects)
+}
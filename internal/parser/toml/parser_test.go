@@ -435,6 +435,111 @@ name = "items"
 	assert.Equal(t, "^[a-z_]+$", db.Validation.AllowedNamePattern)
 }
 
+func TestParseTimestampPolicy(t *testing.T) {
+	const schema = `
+[database]
+name = "testdb"
+dialect = "mysql"
+
+[validation]
+timestamp_policy = "timestamp_only"
+
+[[tables]]
+name = "events"
+
+  [[tables.columns]]
+  name = "id"
+  type = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "starts_at"
+  type = "datetime"
+  raw_type = "DATETIME(3)"
+`
+	_, err := NewParser().Parse(strings.NewReader(schema))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `table "events", column "starts_at": DATETIME(3) violates timestamp_policy "timestamp_only"; use a time-zone aware type such as TIMESTAMP`)
+
+	db, err := NewParser().Parse(strings.NewReader(strings.Replace(schema, "DATETIME(3)", "TIMESTAMP(3)", 1)))
+	require.NoError(t, err)
+	assert.Equal(t, core.TimestampPolicyTimestampOnly, db.Validation.TimestampPolicy)
+
+	portable := strings.Replace(schema, "  raw_type = \"DATETIME(3)\"\n", "", 1)
+	_, err = NewParser().Parse(strings.NewReader(portable))
+	require.Error(t, err)
+	assert.Equal(t, `toml: line 17: table "events", column "starts_at": datetime violates timestamp_policy "timestamp_only"; use a time-zone aware type such as TIMESTAMP`, err.Error())
+
+	_, err = NewParser().Parse(strings.NewReader(strings.Replace(portable, `type = "datetime"`, `type = "timestamp"`, 1)))
+	require.NoError(t, err)
+}
+
+func TestParseNamingRules(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[validation.naming]
+foreign_key = "^fk_"
+index       = "^idx_"
+
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.indexes]]
+  name    = "items_by_id"
+  columns = ["id"]
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Equal(t, &core.NamingRules{ForeignKey: "^fk_", Index: "^idx_"}, db.Validation.Naming)
+	require.Len(t, p.Warnings(), 1)
+	assert.Equal(t, core.WarningNamingConvention, p.Warnings()[0].Code)
+	assert.Equal(t, 18, p.Warnings()[0].Line)
+}
+
+func TestParseRequirePrimaryKey(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[validation]
+require_primary_key = true
+
+[[tables]]
+name = "audit_log"
+allow_no_primary_key = true
+
+  [[tables.columns]]
+  name = "message"
+  type = "text"
+
+[[tables]]
+name = "events"
+
+  [[tables.columns]]
+  name = "payload"
+  type = "text"
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.True(t, db.Validation.RequirePrimaryKey)
+	assert.True(t, db.FindTable("audit_log").AllowNoPrimaryKey)
+	require.Len(t, p.Warnings(), 1)
+	assert.Equal(t, core.WarningMissingPrimaryKey, p.Warnings()[0].Code)
+	assert.Equal(t, "events", p.Warnings()[0].Table)
+	assert.Equal(t, 17, p.Warnings()[0].Line)
+}
+
 func TestParseValidationRulesRejectsLongTableName(t *testing.T) {
 	const schema = `
 [database]
@@ -777,3 +882,317 @@ func TestParseFileExampleSchemaToml(t *testing.T) {
 		assert.Equal(t, name, db.Tables[i].Name)
 	}
 }
+
+func TestParseExtensions(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "postgresql"
+
+[[extensions]]
+name = "citext"
+
+[[extensions]]
+name    = "uuid-ossp"
+schema  = "extensions"
+version = "1.1"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "uuid"
+  primary_key = true
+  default     = "uuid_generate_v4()"
+
+  [[tables.columns]]
+  name     = "email"
+  type     = "text"
+  raw_type = "citext"
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+	assert.Equal(t, []*core.Extension{
+		{Name: "citext"},
+		{Name: "uuid-ossp", Schema: "extensions", Version: "1.1"},
+	}, db.Extensions)
+}
+
+func TestParseMissingExtensionWarning(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "postgresql"
+
+[[tables]]
+name = "users"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name     = "email"
+  type     = "text"
+  raw_type = "citext"
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	require.Len(t, p.Warnings(), 1)
+	assert.Equal(t, core.WarningMissingExtension, p.Warnings()[0].Code)
+	assert.Equal(t, 17, p.Warnings()[0].Line)
+}
+
+func TestParseEnumTypesAndDomains(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "postgresql"
+
+[[enums]]
+name   = "mood"
+values = ["sad", "ok", "happy"]
+
+[[domains]]
+name     = "email"
+type     = "text"
+not_null = true
+check    = "VALUE ~ '@'"
+
+[[tables]]
+name = "people"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "current_mood"
+  type = "mood"
+
+  [[tables.columns]]
+  name = "contact"
+  type = "email"
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+
+	require.Len(t, db.EnumTypes, 1)
+	assert.Equal(t, []string{"sad", "ok", "happy"}, db.EnumTypes[0].Values)
+	assert.Equal(t, &core.Domain{Name: "email", BaseType: "text", NotNull: true, Check: "VALUE ~ '@'"}, db.Domains[0])
+
+	people := db.FindTable("people")
+	mood := people.FindColumn("current_mood")
+	assert.Equal(t, "mood", mood.UserType)
+	assert.Equal(t, core.DataTypeEnum, mood.Type)
+	assert.Equal(t, []string{"sad", "ok", "happy"}, mood.EnumValues)
+
+	contact := people.FindColumn("contact")
+	assert.Equal(t, "email", contact.UserType)
+	assert.Equal(t, core.DataTypeString, contact.Type)
+	assert.Equal(t, "text", contact.RawType)
+}
+
+func TestParseTypeAliases(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[types]
+money    = "decimal(12,2)"
+price    = { type = "money", default = 0, check = "VALUE >= 0" }
+counter  = { type = "int", unsigned = true }
+code     = { type = "varchar(10)", check = "VALUE <> 'NO VALUE' AND upper(value) <> 'VALUE'" }
+
+[[tables]]
+name = "orders"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "counter"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "total"
+  type = "price"
+
+  [[tables.columns]]
+  name    = "discount"
+  type    = "price"
+  default = 5
+
+  [[tables.columns]]
+  name = "sku"
+  type = "code"
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+
+	orders := db.FindTable("orders")
+	id := orders.FindColumn("id")
+	assert.Equal(t, core.DataTypeInt, id.Type)
+	assert.Equal(t, "INT UNSIGNED", id.RawType)
+
+	total := orders.FindColumn("total")
+	assert.Equal(t, core.DataTypeFloat, total.Type)
+	assert.Empty(t, total.UserType, "aliases are expanded, not kept as named types")
+	require.NotNil(t, total.DefaultValue)
+	assert.Equal(t, "0", *total.DefaultValue)
+	assert.Equal(t, "total >= 0", total.Check)
+
+	discount := orders.FindColumn("discount")
+	require.NotNil(t, discount.DefaultValue)
+	assert.Equal(t, "5", *discount.DefaultValue, "column settings win over the alias")
+	assert.Equal(t, "discount >= 0", discount.Check)
+
+	sku := orders.FindColumn("sku")
+	assert.Equal(t, "sku <> 'NO VALUE' AND upper(sku) <> 'VALUE'", sku.Check, "only identifiers are replaced")
+}
+
+func TestParseTypeAliasErrors(t *testing.T) {
+	const table = `
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+`
+	tests := []struct {
+		name  string
+		types string
+		want  string
+	}{
+		{
+			name:  "cycle",
+			types: "[types]\na = \"b\"\nb = \"c\"\nc = \"a\"\n",
+			want:  `toml: line 2: type alias "a": cycle a -> b -> c -> a`,
+		},
+		{
+			name:  "empty type",
+			types: "[types.money]\ndefault = 0\n",
+			want:  `toml: line 1: type alias "money": type is empty`,
+		},
+		{
+			name:  "conflicts with enum",
+			types: "[[enums]]\nname = \"mood\"\nvalues = [\"ok\"]\n\n[types]\nmood = \"text\"\n",
+			want:  `toml: line 6: type alias "mood" conflicts with an enum type or domain of the same name`,
+		},
+		{
+			name:  "unsigned outside mysql",
+			types: "[database]\nname = \"testdb\"\ndialect = \"postgresql\"\n\n[types]\ncounter = { type = \"int\", unsigned = true }\n",
+			want:  `toml: line 6: type alias "counter": unsigned is only supported by mysql, mariadb and tidb, not postgresql`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().Parse(strings.NewReader(tt.types + table))
+			require.Error(t, err)
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
+
+func TestParseUnknownColumnType(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "postgresql"
+
+[types]
+address = "inet"
+
+[[tables]]
+name = "hosts"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "int"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "ip"
+  type = "address"
+
+  [[tables.columns]]
+  name = "network"
+  type = "cidr"
+
+  [[tables.columns]]
+  name     = "search"
+  type     = "tsvector"
+  raw_type = "TSVECTOR"
+`
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	hosts := db.FindTable("hosts")
+	assert.Equal(t, core.DataTypeUnknown, hosts.FindColumn("network").Type)
+	assert.Equal(t, "cidr", hosts.FindColumn("network").RawType, "unknown types are passed through as raw types")
+	assert.Equal(t, "inet", hosts.FindColumn("ip").RawType)
+
+	warnings := p.Warnings()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, core.WarningUnknownType, warnings[0].Code)
+	assert.Equal(t, `type alias "address": unknown type "inet" is passed to postgresql as written`, warnings[0].Message)
+	assert.Equal(t, 7, warnings[0].Line)
+	assert.Equal(t, core.WarningUnknownType, warnings[1].Code)
+	assert.Equal(t, "network", warnings[1].Object)
+	assert.Equal(t, 23, warnings[1].Line)
+}
+
+func TestParseUnknownColumnTypeInvalidForDialect(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name        = "price"
+  type        = "mony"
+  primary_key = true
+`
+	_, err := NewParser().Parse(strings.NewReader(schema))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `column "price": raw_type "mony" (resolved base: "MONY") is not a valid type for dialect "mysql"`)
+}
+
+func TestParseTypeAliasUnknownKeys(t *testing.T) {
+	const schema = `
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[types]
+money = { type = "decimal(12,2)", chekc = "VALUE > 0" }
+
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name        = "price"
+  type        = "money"
+  primary_key = true
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	require.Len(t, p.Warnings(), 1)
+	assert.Equal(t, "types.money.chekc", p.Warnings()[0].Path)
+	assert.Equal(t, `type alias "money"`, p.Warnings()[0].Object)
+}
@@ -0,0 +1,182 @@
+package toml
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"smf/internal/core"
+)
+
+// tomlTypeAlias maps one entry of [types]. An alias is either a bare type
+// string (money = "decimal(12,2)") or a table holding the type together
+// with defaults applied to every column declared with the alias.
+type tomlTypeAlias struct {
+	Type     string `toml:"type"`
+	Default  any    `toml:"default"`
+	Unsigned bool   `toml:"unsigned"`
+	Check    string `toml:"check"`
+}
+
+// UnmarshalTOML accepts both the string and the table form of an alias.
+func (a *tomlTypeAlias) UnmarshalTOML(v any) error {
+	switch val := v.(type) {
+	case string:
+		a.Type = val
+		return nil
+	case map[string]any:
+		if s, ok := val["type"].(string); ok {
+			a.Type = s
+		} else if val["type"] != nil {
+			return fmt.Errorf("type alias: type must be a string, got %T", val["type"])
+		}
+		a.Default = val["default"]
+		if b, ok := val["unsigned"].(bool); ok {
+			a.Unsigned = b
+		} else if val["unsigned"] != nil {
+			return fmt.Errorf("type alias: unsigned must be a boolean, got %T", val["unsigned"])
+		}
+		if s, ok := val["check"].(string); ok {
+			a.Check = s
+		} else if val["check"] != nil {
+			return fmt.Errorf("type alias: check must be a string, got %T", val["check"])
+		}
+		return nil
+	default:
+		return fmt.Errorf("type alias must be a string or a table, got %T", v)
+	}
+}
+
+// typeAlias is a [types] entry with every alias it refers to expanded.
+type typeAlias struct {
+	typ          string
+	defaultValue any
+	unsigned     bool
+	check        string
+}
+
+// checkValue is the placeholder of alias checks, which stands for the name of
+// the column the alias is used on. It matches identifiers in any letter case.
+const checkValue = "VALUE"
+
+// parseTypeAliases expands the [types] section. Aliases may refer to other
+// aliases; the settings of the referring alias win over the ones it refers to.
+func (p *Parser) parseTypeAliases(file string, src *sourceMap, sf *schemaFile) error {
+	p.typeAliases = make(map[string]typeAlias, len(sf.Types))
+	for _, name := range slices.Sorted(maps.Keys(sf.Types)) {
+		if p.userTypes[name] {
+			return aliasError(file, src, name, fmt.Errorf("type alias %q conflicts with an enum type or domain of the same name", name))
+		}
+		if _, err := p.expandAlias(sf.Types, name, nil); err != nil {
+			return aliasError(file, src, name, err)
+		}
+		if d := core.Dialect(strings.ToLower(sf.Database.Dialect)); sf.Types[name].Unsigned && !slices.Contains(unsignedDialects, d) {
+			return aliasError(file, src, name, fmt.Errorf("type alias %q: unsigned is only supported by mysql, mariadb and tidb, not %s", name, d))
+		}
+	}
+	return nil
+}
+
+// unsignedDialects have UNSIGNED integer and decimal types.
+var unsignedDialects = []core.Dialect{core.DialectMySQL, core.DialectMariaDB, core.DialectTiDB}
+
+func (p *Parser) expandAlias(defs map[string]tomlTypeAlias, name string, chain []string) (typeAlias, error) {
+	if a, ok := p.typeAliases[name]; ok {
+		return a, nil
+	}
+	if i := slices.Index(chain, name); i >= 0 {
+		cycle := append(slices.Clone(chain[i:]), name)
+		return typeAlias{}, fmt.Errorf("type alias %q: cycle %s", chain[0], strings.Join(cycle, " -> "))
+	}
+	def := defs[name]
+	target := strings.TrimSpace(def.Type)
+	if target == "" {
+		return typeAlias{}, fmt.Errorf("type alias %q: type is empty", name)
+	}
+
+	a := typeAlias{typ: target}
+	if _, ok := defs[target]; ok {
+		base, err := p.expandAlias(defs, target, append(chain, name))
+		if err != nil {
+			return typeAlias{}, err
+		}
+		a = base
+	}
+	if def.Default != nil {
+		a.defaultValue = def.Default
+	}
+	a.unsigned = a.unsigned || def.Unsigned
+	if def.Check != "" {
+		a.check = def.Check
+	}
+	p.typeAliases[name] = a
+	return a, nil
+}
+
+// expandTypeAlias returns tc with its alias type replaced by the definition.
+// Settings written on the column itself take precedence over the alias.
+func (p *Parser) expandTypeAlias(tc *tomlColumn) *tomlColumn {
+	a, ok := p.typeAliases[strings.TrimSpace(tc.Type)]
+	if !ok {
+		return tc
+	}
+	expanded := *tc
+	expanded.Type = a.typ
+	if expanded.DefaultValue == nil {
+		expanded.DefaultValue = a.defaultValue
+	}
+	if expanded.Check == "" && a.check != "" {
+		expanded.Check = core.ReplaceIdentifier(a.check, checkValue, tc.Name, p.dialect)
+	}
+	if a.unsigned && expanded.RawType == "" {
+		expanded.RawType = strings.ToUpper(a.typ) + " UNSIGNED"
+	}
+	return &expanded
+}
+
+// aliasError locates an error of the [types] entry name.
+func aliasError(file string, src *sourceMap, name string, err error) *ParseError {
+	return &ParseError{File: file, Line: src.locate("types." + name), Err: err}
+}
+
+// unknownTypes warns about [types] entries and columns whose type is neither
+// a portable type, an alias nor an enum type or domain. Such types are passed
+// to dialect as written, which is intended for native types like PostgreSQL
+// inet but also hides misspelled or undeclared aliases.
+func (p *Parser) unknownTypes(sf *schemaFile, dialect *core.Dialect) []core.Warning {
+	unknown := func(typ string) bool {
+		typ = strings.TrimSpace(typ)
+		_, alias := sf.Types[typ]
+		return typ != "" && !alias && !p.userTypes[typ] && core.NormalizeDataType(typ) == core.DataTypeUnknown
+	}
+	var warnings []core.Warning
+	for _, name := range slices.Sorted(maps.Keys(sf.Types)) {
+		if typ := sf.Types[name].Type; unknown(typ) {
+			warnings = append(warnings, core.Warning{
+				Code:    core.WarningUnknownType,
+				Object:  name,
+				Path:    "types." + name,
+				Message: fmt.Sprintf("type alias %q: unknown type %q is passed to %s as written", name, strings.TrimSpace(typ), *dialect),
+			})
+		}
+	}
+	for i := range sf.Tables {
+		table := sf.Tables[i].Name
+		for j := range sf.Tables[i].Columns {
+			tc := &sf.Tables[i].Columns[j]
+			if tc.RawType != "" || !unknown(tc.Type) {
+				continue
+			}
+			warnings = append(warnings, core.Warning{
+				Code:   core.WarningUnknownType,
+				Table:  table,
+				Object: tc.Name,
+				Path:   indexPath(indexPath("tables", i)+".columns", j) + ".type",
+				Message: fmt.Sprintf("table %q, column %q: unknown type %q is passed to %s as written; declare it under [types], or set raw_type if it is a native type",
+					table, tc.Name, strings.TrimSpace(tc.Type), *dialect),
+			})
+		}
+	}
+	return warnings
+}
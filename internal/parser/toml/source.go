@@ -0,0 +1,161 @@
+package toml
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// sourceMap records the line on which every table header and key of a TOML
+// document appears, indexed by key path (e.g. "tables[1].columns[0].name").
+// The TOML decoder does not expose key positions for arrays of tables, so
+// errors and warnings raised after decoding use this map to point back at the
+// right part of the file.
+type sourceMap struct {
+	lines  map[string]int
+	arrays map[string]int
+}
+
+// newSourceMap scans the raw TOML document for headers and key/value lines.
+// It is deliberately lenient: lines it cannot make sense of are skipped.
+func newSourceMap(data []byte) *sourceMap {
+	m := &sourceMap{
+		lines:  make(map[string]int),
+		arrays: make(map[string]int),
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	current := ""
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+		case strings.HasPrefix(text, "[["):
+			current = m.arrayHeader(headerKey(text, "[[", "]]"))
+			m.lines[current] = line
+		case strings.HasPrefix(text, "["):
+			current = m.resolve(headerKey(text, "[", "]"))
+			m.lines[current] = line
+		default:
+			if key, ok := lineKey(text); ok {
+				m.lines[joinPath(current, key)] = line
+			}
+		}
+	}
+	return m
+}
+
+// arrayHeader registers a new element of the array of tables named by key and
+// returns its indexed path.
+func (m *sourceMap) arrayHeader(key string) string {
+	parent, name := "", key
+	if dot := strings.LastIndex(key, "."); dot >= 0 {
+		parent, name = m.resolve(key[:dot]), key[dot+1:]
+	}
+	path := joinPath(parent, name)
+	idx := m.arrays[path]
+	m.arrays[path] = idx + 1
+	return path + "[" + strconv.Itoa(idx) + "]"
+}
+
+// resolve turns a dotted header key into an indexed path by pointing every
+// array-of-tables segment at its most recent element.
+func (m *sourceMap) resolve(key string) string {
+	path := ""
+	for seg := range strings.SplitSeq(key, ".") {
+		path = joinPath(path, seg)
+		if n, ok := m.arrays[path]; ok {
+			path += "[" + strconv.Itoa(n-1) + "]"
+		}
+	}
+	return path
+}
+
+// line returns the line of the given key path, or 0 when unknown.
+func (m *sourceMap) line(path string) int {
+	if m == nil {
+		return 0
+	}
+	return m.lines[path]
+}
+
+// locate returns the line of the given key path. Paths without an entry of
+// their own (such as an option group only written through nested headers)
+// resolve to the first line below them, then to their closest parent.
+func (m *sourceMap) locate(path string) int {
+	if m == nil {
+		return 0
+	}
+	for path != "" {
+		if l := m.lines[path]; l > 0 {
+			return l
+		}
+		first := 0
+		for p, l := range m.lines {
+			if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+				if first == 0 || l < first {
+					first = l
+				}
+			}
+		}
+		if first > 0 {
+			return first
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			return 0
+		}
+		path = path[:cut]
+	}
+	return 0
+}
+
+// tableLine returns the header line of the table at index i, or 0.
+func (m *sourceMap) tableLine(i int) int {
+	return m.line(indexPath("tables", i))
+}
+
+// columnLine returns the header line of column c in table t, or 0.
+func (m *sourceMap) columnLine(t, c int) int {
+	return m.line(indexPath(indexPath("tables", t)+".columns", c))
+}
+
+// headerKey extracts the key between the open and close brackets of a
+// header line, dropping whitespace and quotes.
+func headerKey(text, open, closing string) string {
+	text = strings.TrimPrefix(text, open)
+	if end := strings.Index(text, closing); end >= 0 {
+		text = text[:end]
+	}
+	return normalizeKey(text)
+}
+
+// lineKey returns the key of a "key = value" line.
+func lineKey(text string) (string, bool) {
+	eq := strings.Index(text, "=")
+	if eq <= 0 {
+		return "", false
+	}
+	key := normalizeKey(text[:eq])
+	return key, key != ""
+}
+
+func normalizeKey(key string) string {
+	key = strings.ReplaceAll(key, " ", "")
+	key = strings.ReplaceAll(key, "\t", "")
+	return strings.ReplaceAll(key, `"`, "")
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
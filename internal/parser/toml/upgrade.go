@@ -0,0 +1,236 @@
+package toml
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"smf/internal/core"
+)
+
+// UpgradeChange describes one rewrite made by Upgrade.
+type UpgradeChange struct {
+	// Line is the 1-based line of the original document that was changed.
+	Line int
+	// Message describes the change.
+	Message string
+}
+
+// String returns the change formatted for display.
+func (c UpgradeChange) String() string {
+	return fmt.Sprintf("line %d: %s", c.Line, c.Message)
+}
+
+// checkSchemaFormat rejects documents written for a newer TOML layout than
+// this parser understands.
+func checkSchemaFormat(file string, src *sourceMap, format int) error {
+	if format < 0 || format > SchemaFormat {
+		return &ParseError{File: file, Line: src.line("schema_format"), Err: &SchemaFormatError{Format: format}}
+	}
+	return nil
+}
+
+// legacyEnum is a column that declares its enum values inside the type
+// string instead of through the values key.
+type legacyEnum struct {
+	table, column int
+	values        []string
+}
+
+func (e legacyEnum) path() string {
+	return indexPath(indexPath("tables", e.table)+".columns", e.column) + ".type"
+}
+
+func findLegacyEnums(sf *schemaFile) []legacyEnum {
+	var found []legacyEnum
+	for i := range sf.Tables {
+		for j := range sf.Tables[i].Columns {
+			tc := &sf.Tables[i].Columns[j]
+			if len(tc.EnumValues) > 0 {
+				continue
+			}
+			if values, ok := core.ParseEnumTypeRaw(tc.Type); ok {
+				found = append(found, legacyEnum{table: i, column: j, values: values})
+			}
+		}
+	}
+	return found
+}
+
+// deprecations reports constructs that still parse but that Upgrade rewrites.
+func deprecations(sf *schemaFile) []core.Warning {
+	var warnings []core.Warning
+	for _, e := range findLegacyEnums(sf) {
+		table := sf.Tables[e.table].Name
+		col := &sf.Tables[e.table].Columns[e.column]
+		warnings = append(warnings, core.Warning{
+			Code:   core.WarningDeprecatedSyntax,
+			Table:  table,
+			Object: col.Name,
+			Path:   e.path(),
+			Message: fmt.Sprintf(`table %q, column %q: enum values in type %q are deprecated; use type = "enum" with a values list (see smf upgrade-schema)`,
+				table, col.Name, col.Type),
+		})
+	}
+	return warnings
+}
+
+// Upgrade rewrites a TOML schema document to the current SchemaFormat and
+// returns the new document along with the changes made. Edits are applied
+// line by line so comments and layout are preserved. Constructs it cannot
+// rewrite in place (e.g. columns written as inline tables) are left alone;
+// they keep parsing and keep producing deprecation warnings.
+//
+// file is only used to annotate errors with their location.
+func Upgrade(data []byte, file string) ([]byte, []UpgradeChange, error) {
+	var sf schemaFile
+	if _, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&sf); err != nil {
+		return nil, nil, decodeError(file, err)
+	}
+	src := newSourceMap(data)
+	if err := checkSchemaFormat(file, src, sf.SchemaFormat); err != nil {
+		return nil, nil, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var changes []UpgradeChange
+	// extra holds lines to insert after the line with the given index.
+	extra := make(map[int][]string)
+
+	for _, e := range findLegacyEnums(&sf) {
+		n := src.line(e.path())
+		if n == 0 {
+			continue
+		}
+		rewritten, ok := replaceStringValue(lines[n-1], "enum")
+		if !ok {
+			continue
+		}
+		lines[n-1] = rewritten
+		indent := rewritten[:len(rewritten)-len(strings.TrimLeft(rewritten, " \t"))]
+		extra[n-1] = append(extra[n-1], indent+"values = "+tomlStringArray(e.values)+lineEnding(rewritten))
+		changes = append(changes, UpgradeChange{
+			Line: n,
+			Message: fmt.Sprintf("table %q, column %q: moved enum values from type to values",
+				sf.Tables[e.table].Name, sf.Tables[e.table].Columns[e.column].Name),
+		})
+	}
+
+	out := make([]string, 0, len(lines)+len(extra)+2)
+	if sf.SchemaFormat != SchemaFormat {
+		stamp := "schema_format = " + strconv.Itoa(SchemaFormat)
+		if n := src.line("schema_format"); n > 0 {
+			lines[n-1] = stamp + lineEnding(lines[n-1])
+			changes = append(changes, UpgradeChange{Line: n, Message: "set schema_format to " + strconv.Itoa(SchemaFormat)})
+		} else {
+			at := firstContentLine(lines)
+			eol := lineEnding(lines[at])
+			extra[at-1] = append(extra[at-1], stamp+eol, eol)
+			changes = append(changes, UpgradeChange{Line: at + 1, Message: "added schema_format = " + strconv.Itoa(SchemaFormat)})
+		}
+	}
+
+	out = append(out, extra[-1]...)
+	for i, line := range lines {
+		out = append(out, line)
+		out = append(out, extra[i]...)
+	}
+
+	slices.SortStableFunc(changes, func(a, b UpgradeChange) int { return cmp.Compare(a.Line, b.Line) })
+	return []byte(strings.Join(out, "\n")), changes, nil
+}
+
+// replaceStringValue replaces the quoted string value of a "key = value"
+// line, keeping the key, spacing and any trailing comment.
+func replaceStringValue(line, value string) (string, bool) {
+	eq := strings.Index(line, "=")
+	if eq < 0 {
+		return "", false
+	}
+	rest := strings.TrimLeft(line[eq+1:], " \t")
+	if rest == "" || (rest[0] != '"' && rest[0] != '\'') {
+		return "", false
+	}
+	quote := rest[0]
+	end := -1
+	for i := 1; i < len(rest); i++ {
+		if quote == '"' && rest[i] == '\\' {
+			i++
+			continue
+		}
+		if rest[i] == quote {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return "", false
+	}
+	return line[:eq+1] + " " + tomlQuote(value) + rest[end+1:], true
+}
+
+func tomlStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = tomlQuote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// tomlQuote returns s as a TOML basic string. Unlike strconv.Quote it only
+// uses the escapes TOML defines, writing other control characters as \uXXXX.
+func tomlQuote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// lineEnding returns "\r" for CRLF documents so inserted lines match.
+func lineEnding(line string) string {
+	if strings.HasSuffix(line, "\r") {
+		return "\r"
+	}
+	return ""
+}
+
+// firstContentLine returns the index of the first line that is neither blank
+// nor a comment, so header comments stay at the top of the document.
+func firstContentLine(lines []string) int {
+	for i, line := range lines {
+		text := strings.TrimSpace(line)
+		if text != "" && !strings.HasPrefix(text, "#") {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,166 @@
+package toml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/core"
+)
+
+const legacyEnumSchema = `# Billing schema.
+
+[database]
+name    = "billing"
+dialect = "mysql"
+
+[[tables]]
+name = "accounts"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "plan"
+  type = "enum('free','pro','it''s')" # legacy
+`
+
+func TestParseRejectsNewerSchemaFormat(t *testing.T) {
+	const schema = `schema_format = 99
+
+[database]
+name    = "testdb"
+dialect = "mysql"
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.Error(t, err)
+
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, 1, pe.Line)
+	assert.Contains(t, err.Error(), "schema_format 99 is newer than the supported format 1; upgrade smf")
+}
+
+func TestParseAcceptsCurrentSchemaFormat(t *testing.T) {
+	const schema = `schema_format = 1
+
+[database]
+name    = "testdb"
+dialect = "mysql"
+
+[[tables]]
+name = "items"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+`
+	p := NewParser()
+	_, err := p.Parse(strings.NewReader(schema))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+}
+
+func TestParseLegacyEnumTypeIsDeprecated(t *testing.T) {
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(legacyEnumSchema))
+	require.NoError(t, err)
+
+	col := db.Tables[0].Columns[1]
+	assert.Equal(t, core.DataTypeEnum, col.Type)
+	assert.Equal(t, []string{"free", "pro", "it's"}, col.EnumValues)
+
+	require.Len(t, p.Warnings(), 1)
+	w := p.Warnings()[0]
+	assert.Equal(t, core.WarningDeprecatedSyntax, w.Code)
+	assert.Equal(t, "tables[0].columns[1].type", w.Path)
+	assert.Equal(t, "plan", w.Object)
+}
+
+func TestUpgradeRewritesLegacyEnum(t *testing.T) {
+	out, changes, err := Upgrade([]byte(legacyEnumSchema), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Billing schema.
+
+schema_format = 1
+
+[database]
+name    = "billing"
+dialect = "mysql"
+
+[[tables]]
+name = "accounts"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "plan"
+  type = "enum" # legacy
+  values = ["free", "pro", "it's"]
+`, string(out))
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, "line 3: added schema_format = 1", changes[0].String())
+	assert.Equal(t, `line 17: table "accounts", column "plan": moved enum values from type to values`, changes[1].String())
+
+	p := NewParser()
+	db, err := p.Parse(strings.NewReader(string(out)))
+	require.NoError(t, err)
+	assert.Empty(t, p.Warnings())
+	assert.Equal(t, []string{"free", "pro", "it's"}, db.Tables[0].Columns[1].EnumValues)
+
+	again, changes, err := Upgrade(out, "")
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, string(out), string(again))
+}
+
+func TestUpgradeEscapesControlCharacters(t *testing.T) {
+	const schema = `[database]
+name    = "billing"
+dialect = "mysql"
+
+[[tables]]
+name = "accounts"
+
+  [[tables.columns]]
+  name        = "id"
+  type        = "bigint"
+  primary_key = true
+
+  [[tables.columns]]
+  name = "plan"
+  type = "enum('a\u0001b','tab\there','bell\u0007','del\u007F','\"quoted\"')"
+`
+	out, _, err := Upgrade([]byte(schema), "")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `values = ["a\u0001b", "tab\there", "bell\u0007", "del\u007F", "\"quoted\""]`)
+
+	db, err := NewParser().Parse(strings.NewReader(string(out)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a\x01b", "tab\there", "bell\a", "del\x7f", `"quoted"`}, db.Tables[0].Columns[1].EnumValues)
+}
+
+func TestUpgradeStampsExistingSchemaFormat(t *testing.T) {
+	out, changes, err := Upgrade([]byte("schema_format = 0\n\n[database]\nname = \"x\"\ndialect = \"mysql\"\n"), "")
+	require.NoError(t, err)
+	assert.Equal(t, "schema_format = 1\n\n[database]\nname = \"x\"\ndialect = \"mysql\"\n", string(out))
+	require.Len(t, changes, 1)
+	assert.Equal(t, 1, changes[0].Line)
+}
+
+func TestUpgradeRejectsNewerSchemaFormat(t *testing.T) {
+	_, _, err := Upgrade([]byte("schema_format = 2\n"), "schema.toml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "toml: schema.toml:1: schema_format 2 is newer")
+}
@@ -0,0 +1,36 @@
+package schematest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"smf/internal/parser/toml"
+)
+
+func TestTOMLParses(t *testing.T) {
+	for _, dialect := range []string{"", "postgresql", "sqlite"} {
+		for _, opts := range []Options{{Tables: 1}, {Tables: 20, Columns: 8, Indexes: 3, Seed: 7}} {
+			opts.Dialect = dialect
+			t.Run(fmt.Sprintf("%s/%d", dialect, opts.Tables), func(t *testing.T) {
+				db, err := toml.NewParser().Parse(bytes.NewReader(TOML(opts)))
+				require.NoError(t, err)
+				require.Len(t, db.Tables, opts.Tables)
+				last := db.Tables[opts.Tables-1]
+				assert.Len(t, last.Columns, opts.Columns+min(opts.Tables-1, 1)+1)
+				assert.Len(t, last.Indexes, opts.Indexes)
+			})
+		}
+	}
+}
+
+func TestTOMLDeterministic(t *testing.T) {
+	opts := Options{Tables: 10, Columns: 5, Seed: 1}
+	assert.Equal(t, TOML(opts), TOML(opts))
+	opts2 := opts
+	opts2.Seed = 2
+	assert.NotEqual(t, TOML(opts), TOML(opts2))
+}